@@ -0,0 +1,77 @@
+package uiprogress
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// announcement is the state of a bar when it was last announced
+type announcement struct {
+	current int64
+	done    bool
+}
+
+// SetAccessible makes the progress announce the bars as sentences, like "download: 40 percent, about 2 minutes
+// remaining", instead of drawing them, for screen readers and simple logs. Every interval, each bar that changed
+// since its last announcement is announced on a plain line, and a bar is announced as soon as it completes or fails.
+// An interval of 0 or less turns accessible mode off.
+func (p *Progress) SetAccessible(interval time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if interval < 0 {
+		interval = 0
+	}
+	p.announceEvery = interval
+	p.setPlain(p.wantPlain())
+	p.notifyChange()
+}
+
+// renderAnnouncements prints the announcements of the bars that are due. The caller must hold the lock.
+func (p *Progress) renderAnnouncements() {
+	if p.announced == nil {
+		p.announced = make(map[*Bar]announcement)
+	}
+	now := p.clock.Now()
+	due := p.lastAnnounce.IsZero() || now.Sub(p.lastAnnounce) >= p.announceEvery
+	if due {
+		p.lastAnnounce = now
+	}
+	for _, bar := range p.sortedBars() {
+		last, ok := p.announced[bar]
+		a := announcement{current: bar.Current64(), done: bar.Completed() || bar.Err() != nil}
+		if last.done || (ok && a == last) || (!due && !a.done) {
+			continue
+		}
+		p.announced[bar] = a
+		fmt.Fprintln(p.Out, bar.Announcement())
+	}
+}
+
+// Announcement returns the progress of the bar as a sentence, for example "download: 40 percent, about 2 minutes
+// remaining". Bars without a name are called after their id, like "task 3".
+func (b *Bar) Announcement() string {
+	name := b.Name()
+	if name == "" {
+		name = fmt.Sprintf("task %d", b.ID())
+	}
+	b.mtx.RLock()
+	total, current := b.Total64(), b.current
+	b.mtx.RUnlock()
+	switch {
+	case b.Err() == ErrAborted:
+		return name + ": aborted"
+	case b.Err() != nil:
+		return name + ": failed, " + b.Err().Error()
+	case b.Completed():
+		return name + ": complete"
+	case total <= 0:
+		return fmt.Sprintf("%s: in progress, %d done", name, current)
+	}
+	s := fmt.Sprintf("%s: %d percent", name, int(b.CompletedPercent()))
+	if eta := b.ETA(); eta > 0 {
+		s += ", " + strutil.HumanTime(eta) + " remaining"
+	}
+	return s
+}
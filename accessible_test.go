@@ -0,0 +1,49 @@
+package uiprogress
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetAccessible(t *testing.T) {
+	out := &syncBuffer{}
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.Out = out
+	p.clock = clk
+	bar := p.AddNamedBar("download", 10)
+	p.SetAccessible(time.Second * 10)
+
+	frame := func(d time.Duration) {
+		clk.Sleep(d)
+		p.mtx.Lock()
+		p.render()
+		p.mtx.Unlock()
+	}
+	frame(0)
+	bar.Set(4)
+	frame(time.Second)
+	frame(time.Second * 9)
+	frame(time.Second * 10)
+	bar.Set(10)
+	frame(time.Second)
+
+	want := "download: 0 percent\ndownload: 40 percent\ndownload: complete\n"
+	if got := out.String(); got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestAnnouncement(t *testing.T) {
+	p := New()
+	bar := p.AddBar(0)
+	bar.Set(7)
+	if got := bar.Announcement(); got != "task 0: in progress, 7 done" {
+		t.Fatal("want", "task 0: in progress, 7 done", "got", got)
+	}
+	bar.SetError(errors.New("timeout"))
+	if got := bar.Announcement(); got != "task 0: failed, timeout" {
+		t.Fatal("want", "task 0: failed, timeout", "got", got)
+	}
+}
@@ -0,0 +1,116 @@
+package uiprogress
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// arbiter renders the progress containers sharing a terminal, each in its own region of lines, in the order they
+// were started. Without it, the containers would erase each other's frames.
+type arbiter struct {
+	fd      uintptr
+	lw      *frameWriter
+	order   []*Progress
+	regions map[*Progress][]string
+	// cols is the width of the terminal, as last known by a container drawing its region
+	cols int
+	mtx  *sync.Mutex
+}
+
+var (
+	// arbiters are the arbiters of the terminals in use, keyed by file descriptor
+	arbiters    = make(map[uintptr]*arbiter)
+	arbitersMtx = &sync.Mutex{}
+)
+
+// claim registers p for a region of the arbiter of its output, when the output has a file descriptor, and
+// returns the arbiter. The caller must hold the lock of p.
+func claim(p *Progress) *arbiter {
+	fd, ok := fdOf(p.Out)
+	if !ok {
+		return nil
+	}
+	arbitersMtx.Lock()
+	defer arbitersMtx.Unlock()
+	a := arbiters[fd]
+	if a == nil {
+		lw := newFrameWriter()
+		lw.setLive(p.lw.newLive)
+		lw.setOut(p.Out)
+		a = &arbiter{fd: fd, lw: lw, regions: make(map[*Progress][]string), mtx: &sync.Mutex{}}
+		arbiters[fd] = a
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if _, ok := a.regions[p]; !ok {
+		a.order = append(a.order, p)
+		a.regions[p] = nil
+	}
+	return a
+}
+
+// release gives up the region of p. Its last lines are left on the terminal above the regions of the other
+// containers, which are redrawn below them.
+func (a *arbiter) release(p *Progress) {
+	arbitersMtx.Lock()
+	defer arbitersMtx.Unlock()
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	lines, ok := a.regions[p]
+	if !ok {
+		return
+	}
+	delete(a.regions, p)
+	for i, q := range a.order {
+		if q == p {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+	var buf bytes.Buffer
+	for _, line := range lines {
+		fmt.Fprintln(&buf, line)
+	}
+	a.lw.Bypass().Write(buf.Bytes())
+	a.flush()
+	if len(a.order) == 0 {
+		delete(arbiters, a.fd)
+	}
+}
+
+// draw sets the lines of the region of p and redraws all the regions, returning the bytes written
+func (a *arbiter) draw(p *Progress, lines []string) int {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if _, ok := a.regions[p]; ok {
+		a.regions[p] = lines
+	}
+	a.cols = p.cols
+	return a.flush()
+}
+
+// bypass writes buf above the regions and redraws them
+func (a *arbiter) bypass(buf []byte) (int, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	n, err := a.lw.Bypass().Write(buf)
+	a.flush()
+	return n, err
+}
+
+// forget drops the frame of the regions without erasing it, the next draw draws them anew, see frameWriter.forget
+func (a *arbiter) forget() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.lw.forget()
+}
+
+// flush writes the lines of all the regions as a frame. The caller must hold the lock.
+func (a *arbiter) flush() int {
+	var lines []string
+	for _, p := range a.order {
+		lines = append(lines, a.regions[p]...)
+	}
+	return a.lw.draw(lines, a.cols)
+}
@@ -0,0 +1,46 @@
+package uiprogress
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fdBuffer is a buffer with a file descriptor, standing in for a terminal
+type fdBuffer struct {
+	syncBuffer
+	fd uintptr
+}
+
+func (b *fdBuffer) Fd() uintptr { return b.fd }
+
+func TestArbiter(t *testing.T) {
+	out := &fdBuffer{fd: 1 << 20}
+	p1, p2 := New(), New()
+	p1.Out, p2.Out = out, out
+	a := claim(p1)
+	if claim(p2) != a {
+		t.Fatal("want", "the containers sharing an arbiter")
+	}
+
+	a.draw(p2, []string{"two"})
+	a.draw(p1, []string{"one"})
+	if frame := out.String(); !strings.HasSuffix(frame, clearLines(1)+"one\ntwo\n") {
+		t.Fatalf("want both regions redrawn in order, got %q", frame)
+	}
+
+	a.release(p1)
+	a.draw(p2, []string{"two again"})
+	if frame := out.String(); !strings.HasSuffix(frame, clearLines(2)+"one\n"+"two\n"+clearLines(1)+"two again\n") {
+		t.Fatalf("want the released region left above the others, got %q", frame)
+	}
+	a.release(p2)
+	if _, ok := arbiters[out.fd]; ok {
+		t.Fatal("want", "the arbiter removed with its last region")
+	}
+}
+
+// clearLines returns the sequence the ANSI writer writes to erase n lines
+func clearLines(n int) string {
+	return fmt.Sprintf("\x1b[%dA\r\x1b[J", n)
+}
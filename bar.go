@@ -1,10 +1,11 @@
 package uiprogress
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/gosuri/uiprogress/util/strutil"
@@ -29,13 +30,56 @@ var (
 	// Width is the default width of the progress bar
 	Width = 70
 
+	// MinWidth is the smallest width ChangeWidth sets the bars to, however narrow the terminal
+	MinWidth = 10
+
 	// ErrMaxCurrentReached is error when trying to set current value that exceeds the total value
 	ErrMaxCurrentReached = errors.New("errors: current value is greater total value")
+
+	// SuccessMark is the default mark rendered before the success count
+	SuccessMark = "✓"
+
+	// FailureMark is the default mark rendered before the failure count
+	FailureMark = "✗"
+)
+
+const (
+	// WidthAuto is the bar width that makes the bar fill the line on each render, its track taking the width left
+	// by the decorators
+	WidthAuto = -1
+
+	// bounceWidth is the width of the moving fill of indeterminate bars
+	bounceWidth = 3
+
+	// bounceInterval is the time the fill of indeterminate bars takes to move one character
+	bounceInterval = time.Millisecond * 50
 )
 
 // Bar represents a progress bar
 type Bar struct {
-	// Total of the total  for the progress bar
+	// relaxed is the value last set with SetRelaxed, counted into the bar when relaxedPending is set. It is accessed
+	// atomically and kept first for the 64-bit alignment atomic operations need on 32-bit platforms.
+	relaxed int64
+	// current is the progress of the bar, written with the lock held but stored atomically so it can be read
+	// without the lock
+	current int64
+	// total is the total of the bar, stored atomically like current
+	total int64
+	// version counts the changes of the bar, stored atomically like current
+	version        uint64
+	relaxedPending int32
+	// held keeps the bar rendered as it last was, when its container ran out of render budget for the frame. It is
+	// accessed atomically.
+	held int32
+	// finished is set atomically when the bar was completed with Finish, however its total
+	finished int32
+
+	// label is the text set with SetLabel, stored without the lock
+	label atomic.Value
+
+	// Total of the total  for the progress bar. A total of 0 makes the bar indeterminate, rendering
+	// a bouncing indicator until a total is set. It mirrors the total set with NewBar and SetTotal, use Total64 to read
+	// it while the bar is being updated.
 	Total int
 
 	// LeftEnd is character in the left most part of the progress indicator. Defaults to '['
@@ -56,17 +100,128 @@ type Bar struct {
 	// TimeStated is time progress began
 	TimeStarted time.Time
 
-	// Width is the width of the progress bar
+	// Width is the width of the progress bar, or WidthAuto to fill the line
 	Width int
 
+	// OnCompleteStyle is how the bar is rendered once it completes. CompleteReplace renders CompleteMessage instead.
+	OnCompleteStyle CompleteStyle
+	CompleteMessage string
+
+	// Wrap makes Incr past the total wrap the current value around to 0 and count a lap, instead of stopping.
+	// A wrapping bar never completes.
+	Wrap bool
+
 	// timeElased is the time elapsed for the progress
 	timeElapsed time.Duration
-	current     int
+
+	// laps is the number of times a wrapping bar went past the total
+	laps int
+
+	// successes and failures are informational counters independent of current
+	successes int
+	failures  int
+
+	// id identifies the bar within its progress container
+	id   int
+	name string
+	// lastUpdated is the time current was last changed
+	lastUpdated time.Time
+	// dirty is set when the bar changed since the progress container last checked it
+	dirty bool
+	// cache holds the renderCache of the last rendering, stored without the lock
+	cache atomic.Value
+
+	// lineWidth is the number of cells a WidthAuto bar fills, 0 when unknown
+	lineWidth int
+
+	// changed notifies the progress container of the bar when it changes
+	changed chan<- struct{}
+
+	// parent is the bar b is nested under and weight the share of the child in its completion
+	parent   *Bar
+	weight   float64
+	children []*Bar
+
+	// timer is the duration of a bar advancing with the time elapsed since timerStarted
+	timer        time.Duration
+	timerStarted time.Time
+
+	// progressFunc is polled on each frame for the completion of the bar, from 0.0 to 1.0
+	progressFunc func() float64
+
+	// markers are the glyphs drawn on the track
+	markers []marker
+	// segments are the states of the parts the track is divided into, when set
+	segments []SegmentState
+
+	// overall is set on bars tracking the combined progress of the other bars of their container
+	overall bool
+
+	// group is the section of the progress container the bar is rendered in, when set
+	group *Group
+
+	// startCurrent is the current value when the time started being tracked
+	startCurrent int64
+
+	// rate is the smoothed rate of units per second, rateUnits the units counted since rateSampled
+	rate        float64
+	rateUnits   int64
+	rateSampled time.Time
+	// rates are the recent rate samples, the oldest first
+	rates []float64
+
+	// bounceStarted is the time an indeterminate bar was first rendered
+	bounceStarted time.Time
+
+	// completeFuncs are called when the bar completes, completePending is set until they are called
+	completeFuncs   []func(b *Bar)
+	completePending bool
+
+	// clock measures the time elapsed, the system clock when nil
+	clock Clock
+
+	// theme overrides the characters of the bar when set
+	theme *Theme
+	// colorFunc picks the color of the fill from the completion when set
+	colorFunc ColorFunc
+
+	// unit and units name what the bar counts, in the singular and the plural
+	unit, units string
+
+	// percentPrecision is the number of decimal digits of the completed percent, formatted by percentFormat when set
+	percentPrecision int
+	percentFormat    PercentFormatter
+
+	// overflow is what the bar does when set past its total
+	overflow OverflowPolicy
+
+	// ellipsis is where labels are cut when they don't fit
+	ellipsis strutil.EllipsisPosition
+
+	// err is the error the bar failed with
+	err error
+
+	// stalled is set when the bar received no update since lastUpdated or stallSince for stallTimeout
+	stallTimeout time.Duration
+	stallSince   time.Time
+	stalled      bool
+	onStall      func(b *Bar)
+
+	// tpl formats the bar instead of the decorators when set
+	tpl *template.Template
+
+	// columns lay the bar out instead of the decorators when set
+	columns []Column
+
+	// noTrack hides the progress indicator, rendering only the decorators
+	noTrack bool
+
+	meta map[string]interface{}
 
 	mtx *sync.RWMutex
 
-	appendFuncs  []DecoratorFunc
-	prependFuncs []DecoratorFunc
+	// decorators holds the decoratorFuncs of the bar
+	decorators atomic.Value
 }
 
 // DecoratorFunc is a function that can be prepended and appended to the progress bar
@@ -74,8 +229,14 @@ type DecoratorFunc func(b *Bar) string
 
 // NewBar returns a new progress bar
 func NewBar(total int) *Bar {
+	return NewBar64(int64(total))
+}
+
+// NewBar64 returns a new progress bar with an int64 total, for large counts such as bytes transferred
+func NewBar64(total int64) *Bar {
 	return &Bar{
-		Total:    total,
+		total:    total,
+		Total:    int(total),
 		Width:    Width,
 		LeftEnd:  LeftEnd,
 		RightEnd: RightEnd,
@@ -83,19 +244,33 @@ func NewBar(total int) *Bar {
 		Fill:     Fill,
 		Empty:    Empty,
 
-		mtx: &sync.RWMutex{},
+		dirty: true,
+		mtx:   &sync.RWMutex{},
 	}
 }
 
-// Set the current count of the bar. It returns ErrMaxCurrentReached when trying n exceeds the total value. This is atomic operation and concurancy safe.
+// Set the current count of the bar and updates the time elapsed. It returns ErrMaxCurrentReached when trying n exceeds the total value. This is atomic operation and concurancy safe.
 func (b *Bar) Set(n int) error {
+	return b.Set64(int64(n))
+}
+
+// Set64 is like Set but takes an int64 count
+func (b *Bar) Set64(n int64) error {
+	defer b.notifyComplete()
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
+	return b.set(n)
+}
 
-	if n > b.Total {
-		return ErrMaxCurrentReached
+// set sets the current value to n, checking it against the total. The caller must hold the lock.
+func (b *Bar) set(n int64) error {
+	if total := b.Total64(); total > 0 && n > total {
+		if b.overflow != OverflowExtend {
+			return ErrMaxCurrentReached
+		}
+		b.setTotal(n)
 	}
-	b.current = n
+	b.advance(n)
 	return nil
 }
 
@@ -105,38 +280,319 @@ func (b *Bar) SetWidth(n int) {
 	defer b.mtx.Unlock()
 
 	b.Width = n
+	b.markDirty()
 }
 
 // Incr increments the current value by 1, time elapsed to current time and returns true. It returns false if the cursor has reached or exceeds total value.
+// When Wrap is set, incrementing past the total sets the current value to 0 and counts a lap.
 func (b *Bar) Incr() bool {
+	return b.IncrBy64(1)
+}
+
+// IncrBy increments the current value by n, clamped at the total, and returns true. It returns false if the current
+// value has already reached the total. When Wrap is set, incrementing past the total wraps around like Incr.
+// Increments past the total are handled according to the overflow policy of the bar, see SetOverflowPolicy.
+func (b *Bar) IncrBy(n int) bool {
+	return b.IncrBy64(int64(n))
+}
+
+// IncrBy64 is like IncrBy but takes an int64 increment
+func (b *Bar) IncrBy64(n int64) bool {
+	return b.incr(n) == nil
+}
+
+// incr increments the current value by n. It returns ErrMaxCurrentReached when the increment went past the total
+// and wasn't counted.
+func (b *Bar) incr(n int64) error {
+	defer b.notifyComplete()
+	return b.count(n)
+}
+
+// count increments the current value by n like incr, leaving the call of the OnComplete functions to the caller, see
+// notifyComplete.
+func (b *Bar) count(n int64) error {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 
-	n := b.current + 1
-	if n > b.Total {
-		return false
+	n += b.current
+	if total := b.Total64(); total > 0 && n > total {
+		switch {
+		case b.Wrap:
+			b.laps += int(n / (total + 1))
+			n %= total + 1
+		case b.overflow == OverflowExtend:
+			b.setTotal(n)
+		case b.overflow == OverflowError, b.current >= total:
+			return ErrMaxCurrentReached
+		default:
+			n = total
+		}
 	}
+	b.advance(n)
+	return nil
+}
+
+// advance sets the current value to n and updates the time elapsed. The caller must hold the lock.
+func (b *Bar) advance(n int64) {
+	now := b.timeSource().Now()
 	var t time.Time
 	if b.TimeStarted == t {
-		b.TimeStarted = time.Now()
+		// the first increment reports work done before the bar knew when it started, it doesn't count towards the rate
+		b.TimeStarted = now
+		b.startCurrent = n
+	}
+	if n < b.startCurrent {
+		// the current value was set back for a new phase
+		b.startCurrent = n
+	}
+	b.timeElapsed = now.Sub(b.TimeStarted)
+	b.updateRate(now, n-b.current)
+	wasCompleted := b.completed()
+	atomic.StoreInt64(&b.current, n)
+	b.lastUpdated = now
+	b.stalled = false
+	b.markDirty()
+	b.completePending = b.completePending || (!wasCompleted && b.completed())
+}
+
+// SetTotal sets the total of the bar, for work that is discovered while it is being done. The current value is clamped
+// at the new total. A total of 0 makes the bar indeterminate.
+func (b *Bar) SetTotal(n int) {
+	b.SetTotal64(int64(n))
+}
+
+// SetTotal64 is like SetTotal but takes an int64 total
+func (b *Bar) SetTotal64(n int64) {
+	defer b.notifyComplete()
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	wasCompleted := b.completed()
+	b.setTotal(n)
+	atomic.StoreInt32(&b.finished, 0)
+	if n > 0 && b.current > n {
+		atomic.StoreInt64(&b.current, n)
+	}
+	b.markDirty()
+	b.completePending = b.completePending || (!wasCompleted && b.completed())
+}
+
+// Finish completes the bar with n as both its current value and total, for work whose total was only an estimate.
+// A bar finished at 0, like one counting an empty copy, completes too, unlike a bar set to a total of 0.
+// Setting the total afterwards makes the bar incomplete again.
+func (b *Bar) Finish(n int) {
+	b.Finish64(int64(n))
+}
+
+// Finish64 is like Finish but takes an int64 count
+func (b *Bar) Finish64(n int64) {
+	defer b.notifyComplete()
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	wasCompleted := b.completed()
+	atomic.StoreInt32(&b.finished, 1)
+	b.setTotal(n)
+	b.advance(n)
+	b.completePending = b.completePending || (!wasCompleted && b.completed())
+}
+
+// setTotal stores the total of the bar and its int mirror. The caller must hold the lock.
+func (b *Bar) setTotal(n int64) {
+	atomic.StoreInt64(&b.total, n)
+	b.Total = int(n)
+}
+
+// Total64 returns the total of the bar as an int64. It doesn't take the lock and is safe to call while the bar is
+// being updated.
+func (b *Bar) Total64() int64 {
+	return atomic.LoadInt64(&b.total)
+}
+
+// CompleteStyle is how a bar is rendered by its progress container once it completes
+type CompleteStyle int
+
+const (
+	// CompleteLeave keeps rendering the full bar
+	CompleteLeave CompleteStyle = iota
+
+	// CompleteClear removes the line of the bar
+	CompleteClear
+
+	// CompleteReplace renders the CompleteMessage of the bar in place of it
+	CompleteReplace
+)
+
+// SetOnCompleteStyle sets how the bar is rendered once it completes, with the message for CompleteReplace
+func (b *Bar) SetOnCompleteStyle(style CompleteStyle, message string) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.OnCompleteStyle = style
+	b.CompleteMessage = message
+	b.markDirty()
+	return b
+}
+
+// OnComplete registers f to be called when the current value reaches the total. It is called every time the bar
+// becomes complete, from the goroutine that completed it and without any lock held, so f can update the bar or
+// remove it from its progress container.
+func (b *Bar) OnComplete(f func(b *Bar)) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.completeFuncs = append(b.completeFuncs, f)
+	return b
+}
+
+// notifyComplete calls the OnComplete functions if the bar became complete and updates the parent of the bar.
+// It must be called without the lock held.
+func (b *Bar) notifyComplete() {
+	if b.parent != nil {
+		defer b.parent.aggregate()
+	}
+	b.mtx.Lock()
+	if !b.completePending {
+		b.mtx.Unlock()
+		return
+	}
+	b.completePending = false
+	fns := b.completeFuncs
+	b.mtx.Unlock()
+
+	for _, f := range fns {
+		f(b)
 	}
-	b.timeElapsed = time.Since(b.TimeStarted)
-	b.current = n
-	return true
 }
 
 // Current returns the current progress of the bar
 func (b *Bar) Current() int {
+	return int(b.Current64())
+}
+
+// Current64 returns the current progress of the bar as an int64. It doesn't wait for the bar to be unlocked, so
+// rendering never blocks the goroutines updating the bar.
+func (b *Bar) Current64() int64 {
+	return atomic.LoadInt64(&b.current)
+}
+
+// Laps returns the number of times a wrapping bar went past the total
+func (b *Bar) Laps() int {
 	b.mtx.RLock()
 	defer b.mtx.RUnlock()
-	return b.current
+	return b.laps
+}
+
+// Completed reports whether the current value reached the total. It is always false for wrapping bars.
+func (b *Bar) Completed() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.completed()
+}
+
+// completed reports whether the bar is complete. The caller must hold the lock.
+func (b *Bar) completed() bool {
+	if b.Wrap {
+		return false
+	}
+	total := b.Total64()
+	return atomic.LoadInt32(&b.finished) != 0 || (total > 0 && b.current >= total)
+}
+
+// animated reports whether the bar changes on every render, like indeterminate bars, spinners and running timer bars
+func (b *Bar) animated() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.indeterminate() || ((b.timer > 0 || b.progressFunc != nil) && !b.completed())
+}
+
+// indeterminate reports whether the bar has no total to show its progress against. It doesn't take the lock.
+func (b *Bar) indeterminate() bool {
+	return b.Total64() <= 0 && atomic.LoadInt32(&b.finished) == 0
+}
+
+// SetName sets the name of the bar, used to identify it in events
+func (b *Bar) SetName(name string) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.name = name
+	return b
+}
+
+// Name returns the name of the bar
+func (b *Bar) Name() string {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.name
+}
+
+// ID returns the identifier of the bar, assigned in the order bars are added to a progress container
+func (b *Bar) ID() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.id
+}
+
+// LastUpdated returns the time the current value was last changed
+func (b *Bar) LastUpdated() time.Time {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.lastUpdated
+}
+
+// markDirty flags the bar as changed and notifies its progress container. The caller must hold the lock.
+func (b *Bar) markDirty() {
+	b.dirty = true
+	atomic.AddUint64(&b.version, 1)
+	select {
+	case b.changed <- struct{}{}:
+	default:
+	}
+}
+
+// takeDirty reports whether the bar changed since the last call and resets the dirty flag
+func (b *Bar) takeDirty() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	dirty := b.dirty
+	b.dirty = false
+	return dirty
+}
+
+// IncrSuccess increments the success count by 1 and returns the new count. It does not change the current value of the bar.
+func (b *Bar) IncrSuccess() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.successes++
+	b.markDirty()
+	return b.successes
+}
+
+// IncrFailure increments the failure count by 1 and returns the new count. It does not change the current value of the bar.
+func (b *Bar) IncrFailure() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.failures++
+	b.markDirty()
+	return b.failures
+}
+
+// Successes returns the success count of the bar
+func (b *Bar) Successes() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.successes
+}
+
+// Failures returns the failure count of the bar
+func (b *Bar) Failures() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.failures
 }
 
 // AppendFunc runs the decorator function and renders the output on the right of the progress bar
 func (b *Bar) AppendFunc(f DecoratorFunc) *Bar {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
-	b.appendFuncs = append(b.appendFuncs, f)
+	b.addFunc(f, false)
+	b.markDirty()
 	return b
 }
 
@@ -156,11 +612,28 @@ func (b *Bar) AppendElapsed() *Bar {
 	return b
 }
 
+// AppendResults appends the success and failure counts to the progress bar, colored green and red when color is true
+func (b *Bar) AppendResults(color bool) *Bar {
+	b.AppendFunc(func(b *Bar) string {
+		return b.ResultsString(color)
+	})
+	return b
+}
+
+// AppendLaps appends the lap count of a wrapping bar to the progress bar
+func (b *Bar) AppendLaps() *Bar {
+	b.AppendFunc(func(b *Bar) string {
+		return b.LapsString()
+	})
+	return b
+}
+
 // PrependFunc runs decorator function and render the output left the progress bar
 func (b *Bar) PrependFunc(f DecoratorFunc) *Bar {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
-	b.prependFuncs = append(b.prependFuncs, f)
+	b.addFunc(f, true)
+	b.markDirty()
 	return b
 }
 
@@ -180,56 +653,369 @@ func (b *Bar) PrependElapsed() *Bar {
 	return b
 }
 
+// PrependResults prepends the success and failure counts to the progress bar, colored green and red when color is true
+func (b *Bar) PrependResults(color bool) *Bar {
+	b.PrependFunc(func(b *Bar) string {
+		return b.ResultsString(color)
+	})
+	return b
+}
+
+// PrependLaps prepends the lap count of a wrapping bar to the progress bar
+func (b *Bar) PrependLaps() *Bar {
+	b.PrependFunc(func(b *Bar) string {
+		return b.LapsString()
+	})
+	return b
+}
+
+// renderTrack renders the track with the theme of the bar, its fill colored by the color function of the bar when set,
+// and in red when the bar failed
+func (b *Bar) renderTrack(track []Style) []byte {
+	theme := b.trackTheme(len(track))
+	if f := b.fillColor(); f != nil {
+		c := f(b.CompletedPercent())
+		theme.FillColor, theme.HeadColor = c, c
+	}
+	if b.Err() != nil {
+		theme.FillColor, theme.HeadColor = ColorRed, ColorRed
+	}
+	if states := b.Segments(); len(states) > 0 {
+		return theme.renderSegments(track, states, b.markGlyphs(track))
+	}
+	return theme.renderMarked(track, b.markGlyphs(track))
+}
+
+// autoWidth returns the track width that makes the bar fill its line next to the decorations. It returns the default
+// Width when the line width is unknown.
+func (b *Bar) autoWidth(prepends, appends []string) int {
+	b.mtx.RLock()
+	width := b.lineWidth
+	b.mtx.RUnlock()
+	if width <= 0 {
+		return Width
+	}
+	for _, s := range append(prepends, appends...) {
+		width -= strutil.Width(s) + 1
+	}
+	return width
+}
+
+// setLineWidth sets the number of cells a WidthAuto bar fills
+func (b *Bar) setLineWidth(n int) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.lineWidth != n {
+		b.lineWidth = n
+		b.markDirty()
+	}
+}
+
 // Bytes returns the byte presentation of the progress bar
 func (b *Bar) Bytes() []byte {
-	completedWidth := int(float64(b.Width) * (b.CompletedPercent() / 100.00))
+	var buf []byte
+	for _, part := range b.lineParts(b.Width) {
+		if part.track != nil {
+			buf = append(buf, b.renderTrack(part.track)...)
+			continue
+		}
+		buf = append(buf, part.text...)
+	}
+	return buf
+}
 
-	// add fill and empty bits
-	var buf bytes.Buffer
-	for i := 0; i < completedWidth; i++ {
-		buf.WriteByte(b.Fill)
+// linePart is a piece of the line of a bar: the track of its progress indicator when track is set, text otherwise
+type linePart struct {
+	text  string
+	track []Style
+}
+
+// lineParts lays the line of the bar out with a progress indicator of the given width, or filling the line for
+// WidthAuto. Bytes and RenderCells both render the line from its parts.
+func (b *Bar) lineParts(width int) []linePart {
+	if tpl := b.template(); tpl != nil {
+		return b.templateParts(tpl, width)
+	}
+	if cols := b.layout(); cols != nil {
+		return b.columnsParts(cols, width)
+	}
+	prepends, appends := b.decorations()
+
+	var track []Style
+	if !b.noTrack {
+		if width == WidthAuto {
+			prepends = b.fitPrepends(prepends, appends)
+			width = b.autoWidth(prepends, appends)
+		}
+		track = b.track(width)
+	}
+	failure := b.ErrorString()
+	stalled := b.Stalled()
+
+	// render prepend functions to the left of the bar, the last added being the left most
+	var before []byte
+	for i := len(prepends) - 1; i >= 0; i-- {
+		before = append(before, prepends[i]...)
+		before = append(before, ' ')
 	}
-	for i := 0; i < b.Width-completedWidth; i++ {
-		buf.WriteByte(b.Empty)
+	if len(track) == 0 && len(before) > 0 {
+		before = before[:len(before)-1]
+	}
+
+	// render append functions to the right of the bar
+	var after []byte
+	empty := len(before) == 0 && len(track) == 0
+	for i, s := range appends {
+		if i > 0 || !empty {
+			after = append(after, ' ')
+		}
+		after = append(after, s...)
+	}
+	empty = empty && len(after) == 0
+	if failure != "" {
+		if !empty {
+			after = append(after, ' ')
+		}
+		after = append(after, ColorRed.Paint(failure)...)
+		empty = false
+	}
+	if stalled {
+		if !empty {
+			after = append(after, ' ')
+		}
+		after = append(after, ColorYellow.Paint(StalledText)...)
+	}
+
+	parts := []linePart{{text: string(before)}}
+	if len(track) > 0 {
+		parts = append(parts, linePart{track: track})
+	}
+	return append(parts, linePart{text: string(after)})
+}
+
+// track returns the style of each column of the progress indicator for the given width
+func (b *Bar) track(width int) []Style {
+	if width <= 0 {
+		return nil
+	}
+	if b.indeterminate() {
+		return b.bounceTrack(width)
+	}
+	if theme := b.Theme(); theme.Partials != "" {
+		return b.smoothTrack(width, theme)
+	}
+	completedWidth := int(float64(width) * (b.CompletedPercent() / 100.00))
+
+	// add fill and empty bits
+	track := make([]Style, width)
+	for i := range track {
+		if i < completedWidth {
+			track[i] = StyleFill
+		} else {
+			track[i] = StyleEmpty
+		}
 	}
 
 	// set head bit
-	pb := buf.Bytes()
-	if completedWidth > 0 && completedWidth < b.Width {
-		pb[completedWidth-1] = b.Head
+	if completedWidth > 0 && completedWidth < width {
+		track[completedWidth-1] = StyleHead
 	}
 
 	// set left and right ends bits
-	pb[0], pb[len(pb)-1] = b.LeftEnd, b.RightEnd
+	track[0], track[width-1] = StyleLeftEnd, StyleRightEnd
+	return track
+}
 
-	// render append functions to the right of the bar
-	for _, f := range b.appendFuncs {
-		pb = append(pb, ' ')
-		pb = append(pb, []byte(f(b))...)
+// bounceTrack returns the track of an indeterminate bar, with a short fill moving back and forth between the ends
+func (b *Bar) bounceTrack(width int) []Style {
+	track := make([]Style, width)
+	for i := range track {
+		track[i] = StyleEmpty
 	}
+	b.mtx.Lock()
+	if b.bounceStarted.IsZero() {
+		b.bounceStarted = b.timeSource().Now()
+	}
+	step := int(b.timeSource().Since(b.bounceStarted) / bounceInterval)
+	b.mtx.Unlock()
+
+	pos := bouncePosition(step, width-2-bounceWidth)
+	for i := 0; i < bounceWidth && 1+pos+i < width-1; i++ {
+		track[1+pos+i] = StyleFill
+	}
+	track[0], track[width-1] = StyleLeftEnd, StyleRightEnd
+	return track
+}
+
+// bouncePosition returns the position at step of an indicator moving back and forth between 0 and max
+func bouncePosition(step, max int) int {
+	if max <= 0 {
+		return 0
+	}
+	step %= 2 * max
+	if step > max {
+		return 2*max - step
+	}
+	return step
+}
+
+// decoratorFuncs are the decorator functions of a bar. They are replaced rather than modified when a function is
+// added, for the render path to read them without the lock.
+type decoratorFuncs struct {
+	prepends, appends []DecoratorFunc
+}
+
+// funcs returns the decorator functions of the bar
+func (b *Bar) funcs() decoratorFuncs {
+	funcs, _ := b.decorators.Load().(decoratorFuncs)
+	return funcs
+}
 
-	// render prepend functions to the left of the bar
-	for _, f := range b.prependFuncs {
-		args := []byte(f(b))
-		args = append(args, ' ')
-		pb = append(args, pb...)
+// addFunc adds the decorator function f on the left of the bar when prepend is set, on the right otherwise.
+// The caller must hold the lock.
+func (b *Bar) addFunc(f DecoratorFunc, prepend bool) {
+	funcs := b.funcs()
+	// the full slice expressions make append copy the functions other renders may be reading
+	if prepend {
+		funcs.prepends = append(funcs.prepends[:len(funcs.prepends):len(funcs.prepends)], f)
+	} else {
+		funcs.appends = append(funcs.appends[:len(funcs.appends):len(funcs.appends)], f)
 	}
-	return pb
+	b.decorators.Store(funcs)
 }
 
-// String returns the string representation of the bar
+// decorations runs the decorator functions and returns their output, in the order they were added
+func (b *Bar) decorations() (prepends, appends []string) {
+	funcs := b.funcs()
+	for _, f := range funcs.prepends {
+		prepends = append(prepends, f(b))
+	}
+	for _, f := range funcs.appends {
+		appends = append(appends, f(b))
+	}
+	return prepends, appends
+}
+
+// String returns the string representation of the bar, cached until the bar changes. Decorators are expected to
+// depend only on the state of the bar, call Invalidate when they render something else that changed.
+// The cached rendering is returned without taking the lock, so rendering doesn't hold up the updates of the bar.
 func (b *Bar) String() string {
-	return string(b.Bytes())
+	key, cached := b.renderKey(), b.cached()
+	if (key.version > 0 && key == cached.key) || (atomic.LoadInt32(&b.held) != 0 && cached.s != "") {
+		return cached.s
+	}
+
+	s := string(b.Bytes())
+	if !b.animated() {
+		b.cache.Store(renderCache{key: key, s: s})
+	}
+	return s
+}
+
+// cached returns the last rendering of the bar, empty if it wasn't rendered yet
+func (b *Bar) cached() renderCache {
+	c, _ := b.cache.Load().(renderCache)
+	return c
+}
+
+// Invalidate discards the cached rendering of the bar, for decorators rendering state other than the bar's own
+func (b *Bar) Invalidate() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.markDirty()
+}
+
+// renderKey is the state of a bar its rendering is cached for
+type renderKey struct {
+	version                              uint64
+	width                                int
+	total                                int64
+	fill, head, empty, leftEnd, rightEnd byte
+	wrap                                 bool
+	label                                string
+}
+
+// renderCache is a rendering of a bar and the key it was rendered at
+type renderCache struct {
+	key renderKey
+	s   string
+}
+
+// renderKey returns the current render key of the bar. It doesn't take the lock, the exported fields being read like
+// Bytes reads them.
+func (b *Bar) renderKey() renderKey {
+	return renderKey{
+		version:  atomic.LoadUint64(&b.version),
+		width:    b.Width,
+		total:    b.Total64(),
+		fill:     b.Fill,
+		head:     b.Head,
+		empty:    b.Empty,
+		leftEnd:  b.LeftEnd,
+		rightEnd: b.RightEnd,
+		wrap:     b.Wrap,
+		label:    b.Label(),
+	}
 }
 
-// CompletedPercent return the percent completed
+// CompletedPercent return the percent completed. It is 0 for indeterminate bars.
 func (b *Bar) CompletedPercent() float64 {
-	return (float64(b.Current()) / float64(b.Total)) * 100.00
+	total := b.Total64()
+	if total <= 0 {
+		if b.indeterminate() {
+			return 0
+		}
+		return 100
+	}
+	return (float64(b.Current64()) / float64(total)) * 100.00
 }
 
 // CompletedPercentString returns the formatted string representation of the completed percent
 func (b *Bar) CompletedPercentString() string {
-	return fmt.Sprintf("%3.f%%", b.CompletedPercent())
+	b.mtx.RLock()
+	format, precision := b.percentFormat, b.percentPrecision
+	b.mtx.RUnlock()
+	if format == nil {
+		format = DefaultPercentFormat
+	}
+	return format(b.CompletedPercent(), precision)
+}
+
+// SummaryString returns the final state of the bar with its elapsed time and average rate, for example
+// "download: 10/10 (100%) in 2s, 5.0/s"
+func (b *Bar) SummaryString() string {
+	b.mtx.RLock()
+	name, current, total, elapsed := b.name, b.current, b.Total64(), b.timeElapsed
+	b.mtx.RUnlock()
+	if name == "" {
+		name = fmt.Sprintf("bar %d", b.ID())
+	}
+
+	summary := fmt.Sprintf("%s: %d", name, current)
+	if total > 0 {
+		summary += fmt.Sprintf("/%d (%.f%%)", total, b.CompletedPercent())
+	}
+	summary += " in " + strutil.PrettyTime(elapsed)
+	if elapsed > 0 {
+		summary += fmt.Sprintf(", %.1f/s", float64(current)/elapsed.Seconds())
+	}
+	return summary
+}
+
+// ResultsString returns the formatted success and failure counts, for example "✓12 ✗3"
+func (b *Bar) ResultsString(color bool) string {
+	ok := fmt.Sprintf("%s%d", SuccessMark, b.Successes())
+	fail := fmt.Sprintf("%s%d", FailureMark, b.Failures())
+	if color {
+		ok = ColorGreen.Paint(ok)
+		fail = ColorRed.Paint(fail)
+	}
+	return ok + " " + fail
+}
+
+// LapsString returns the formatted lap count, for example "lap 2"
+func (b *Bar) LapsString() string {
+	return fmt.Sprintf("lap %d", b.Laps())
 }
 
 // TimeElapsed returns the time elapsed
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
 )
 
 func TestBarPrepend(t *testing.T) {
@@ -35,3 +37,288 @@ func TestBarIncr(t *testing.T) {
 		t.Fatal("need", 10000, "got", b.Current())
 	}
 }
+
+func TestBarResults(t *testing.T) {
+	b := NewBar(100).AppendResults(false)
+	for i := 0; i < 12; i++ {
+		b.IncrSuccess()
+	}
+	for i := 0; i < 3; i++ {
+		b.IncrFailure()
+	}
+	if b.Current() != 0 {
+		t.Fatal("want", 0, "got", b.Current())
+	}
+	if !strings.HasSuffix(b.String(), " ✓12 ✗3") {
+		t.Fatal("want", "✓12 ✗3", "in", b.String())
+	}
+
+	got := b.ResultsString(true)
+	want := string(ColorGreen) + "✓12" + colorReset + " " + string(ColorRed) + "✗3" + colorReset
+	if got != want {
+		t.Fatalf("want %q got %q", want, got)
+	}
+}
+
+func TestBarWrap(t *testing.T) {
+	b := NewBar(3).AppendLaps()
+	b.Wrap = true
+	for i := 0; i < 8; i++ {
+		if !b.Incr() {
+			t.Fatal("want", "wrapping bar to keep incrementing")
+		}
+	}
+	if b.Current() != 0 || b.Laps() != 2 {
+		t.Fatal("want", 0, 2, "got", b.Current(), b.Laps())
+	}
+	if b.Completed() {
+		t.Fatal("want", "wrapping bar to never complete")
+	}
+	if !strings.HasSuffix(b.String(), " lap 2") {
+		t.Fatal("want", "lap 2", "in", b.String())
+	}
+}
+
+func TestBar64(t *testing.T) {
+	total := int64(8) << 30 // 8 GiB
+	b := NewBar64(total)
+	if err := b.Set64(total / 2); err != nil {
+		t.Fatal(err)
+	}
+	if b.Current64() != total/2 {
+		t.Fatal("want", total/2, "got", b.Current64())
+	}
+	if b.CompletedPercent() != 50 {
+		t.Fatal("want", 50, "got", b.CompletedPercent())
+	}
+	if b.Set64(total+1) != ErrMaxCurrentReached {
+		t.Fatal("want", ErrMaxCurrentReached)
+	}
+}
+
+func TestBarSetTotal(t *testing.T) {
+	b := NewBar(0)
+	b.Width = 10
+	for i := 0; i < 5; i++ {
+		if !b.Incr() {
+			t.Fatal("want", "indeterminate bar to keep incrementing")
+		}
+	}
+	if b.Completed() || b.CompletedPercent() != 0 {
+		t.Fatal("want", "indeterminate bar not completed", "got", b.CompletedPercent())
+	}
+	if got := b.String(); got != "[===-----]" {
+		t.Fatal("want", "[===-----]", "got", got)
+	}
+
+	b.SetTotal(10)
+	if b.CompletedPercent() != 50 {
+		t.Fatal("want", 50, "got", b.CompletedPercent())
+	}
+	b.SetTotal(4)
+	if b.Current() != 4 || !b.Completed() {
+		t.Fatal("want", 4, "got", b.Current())
+	}
+}
+
+func TestBarSetTotalWhileRendering(t *testing.T) {
+	parent := NewBar(100).AppendCompleted()
+	child := parent.AddChild(10, 1)
+	tpl := NewBar(10)
+	if err := tpl.SetTemplate("{{.Current}}/{{.Total}} [{{.Bar}}]"); err != nil {
+		t.Fatal(err)
+	}
+	bars := []*Bar{parent, child, tpl}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int64(0); i < 500; i++ {
+			for _, b := range bars {
+				b.SetTotal64(i % 20)
+				b.Set64(i % 10)
+			}
+			runtime.Gosched()
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		for _, b := range bars {
+			if percent := b.CompletedPercent(); percent < 0 || percent > 100 {
+				t.Fatal("want", "percent within 0 and 100", "got", percent)
+			}
+			if b.String() == "" {
+				t.Fatal("want", "bar rendered")
+			}
+		}
+		parent.aggregate()
+		runtime.Gosched()
+	}
+}
+
+func TestBouncePosition(t *testing.T) {
+	want := []int{0, 1, 2, 3, 2, 1, 0, 1}
+	for step, pos := range want {
+		if got := bouncePosition(step, 3); got != pos {
+			t.Fatal("want", pos, "at", step, "got", got)
+		}
+	}
+}
+
+func TestBarOnComplete(t *testing.T) {
+	p := New()
+	b := p.AddBar(3)
+	calls := 0
+	b.OnComplete(func(b *Bar) {
+		calls++
+		p.RemoveBar(b)
+	})
+
+	for b.Incr() {
+	}
+	b.Set(3)
+	if calls != 1 {
+		t.Fatal("want", 1, "got", calls)
+	}
+	if len(p.Bars) != 0 {
+		t.Fatal("want", "bar removed on completion")
+	}
+
+	b.Set(1)
+	b.SetTotal(1)
+	if calls != 2 {
+		t.Fatal("want", 2, "got", calls)
+	}
+}
+
+func TestBarWidthAuto(t *testing.T) {
+	b := NewBar(10).PrependFunc(func(*Bar) string { return "name" }).AppendCompleted()
+	b.Width = WidthAuto
+	if got := len(b.String()); got != len("name ")+Width+len("   0%") {
+		t.Fatal("want", "default width when the line width is unknown", "got", got)
+	}
+	b.setLineWidth(40)
+	if got := strutil.Width(b.String()); got != 40 {
+		t.Fatal("want", 40, "got", got)
+	}
+}
+
+func TestSummaryString(t *testing.T) {
+	b := NewBar(10).SetName("download")
+	b.Set(10)
+	b.timeElapsed = time.Second * 2
+	if got, want := b.SummaryString(), "download: 10/10 (100%) in 2s, 5.0/s"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
+
+func TestIncrBy(t *testing.T) {
+	b := NewBar(10)
+	if !b.IncrBy(4) || !b.IncrBy(8) || b.Current() != 10 {
+		t.Fatal("want", 10, "got", b.Current())
+	}
+	if b.IncrBy(1) {
+		t.Fatal("want", "false once the total is reached")
+	}
+
+	b = NewBar(10)
+	b.Wrap = true
+	b.IncrBy(25)
+	if b.Current() != 3 || b.Laps() != 2 {
+		t.Fatal("want", "3 after 2 laps", "got", b.Current(), b.Laps())
+	}
+}
+
+func TestIncrByRate(t *testing.T) {
+	b := NewBar(100)
+	b.IncrBy(50)
+	b.TimeStarted = b.TimeStarted.Add(-time.Second)
+	b.IncrBy(10)
+	if rate := b.Rate(); rate < 9 || rate > 11 {
+		t.Fatal("want", "rate of 10/s without the first increment", "got", rate)
+	}
+}
+
+func BenchmarkBarString(b *testing.B) {
+	bar := NewBar(100).AppendCompleted().PrependElapsed()
+	bar.Set(50)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = bar.String()
+	}
+}
+
+func BenchmarkBarStringChanged(b *testing.B) {
+	bar := NewBar(b.N + 1).AppendCompleted().PrependElapsed()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bar.Incr()
+		_ = bar.String()
+	}
+}
+
+func BenchmarkBarParallel(b *testing.B) {
+	bar := NewBar(b.N + 1).AppendCompleted().PrependElapsed()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%100 == 0 {
+				_ = bar.String()
+			} else {
+				bar.Incr()
+			}
+		}
+	})
+}
+
+func TestBarConcurrentDecorators(t *testing.T) {
+	bar := NewBar(1000)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				bar.AppendFunc(func(*Bar) string { return "x" }).Incr()
+				_ = bar.String()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for j := 0; j <= 50; j++ {
+			bar.SetTotal(1000 + j)
+			_ = bar.String()
+		}
+	}()
+	wg.Wait()
+	if got := len(bar.funcs().appends); got != 200 || bar.Current() != 200 {
+		t.Fatal("want", 200, "got", got, bar.Current())
+	}
+	if bar.Total64() != 1050 || bar.Total != 1050 {
+		t.Fatal("want", 1050, "got", bar.Total64(), bar.Total)
+	}
+}
+
+func TestBarStringCache(t *testing.T) {
+	name := "a"
+	bar := NewBar(10).PrependFunc(func(*Bar) string { return name })
+	first := bar.String()
+	name = "b"
+	if got := bar.String(); got != first {
+		t.Fatal("want", "cached", first, "got", got)
+	}
+	bar.Invalidate()
+	if got := bar.String(); got == first {
+		t.Fatal("want", "rendered again after Invalidate", "got", got)
+	}
+	bar.Width = 20
+	if got := bar.String(); len(got) != len("b ")+20 {
+		t.Fatal("want", "rendered again after a width change", "got", got)
+	}
+}
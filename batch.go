@@ -0,0 +1,28 @@
+package uiprogress
+
+// Update calls f with the bars of the container while holding the render lock, so a batch of updates to many bars
+// is drawn in a single frame and never half applied. f, and the complete callbacks of the bars it completes, must
+// not call the methods of the container.
+func (p *Progress) Update(f func(bars []*Bar)) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	f(p.Bars)
+}
+
+// ApplyDeltas increments each bar by its delta in a single Update. Increments past the total are handled according to
+// the overflow policy of each bar. It returns the first error met, after applying all the deltas. The complete
+// callbacks of the bars completed are called once the container is unlocked, so they can call its methods.
+func (p *Progress) ApplyDeltas(deltas map[*Bar]int) error {
+	var first error
+	p.Update(func([]*Bar) {
+		for bar, n := range deltas {
+			if err := bar.count(int64(n)); err != nil && first == nil {
+				first = err
+			}
+		}
+	})
+	for bar := range deltas {
+		bar.notifyComplete()
+	}
+	return first
+}
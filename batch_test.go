@@ -0,0 +1,38 @@
+package uiprogress
+
+import "testing"
+
+func TestApplyDeltas(t *testing.T) {
+	p := New()
+	a, b := p.AddBar(10), p.AddBar(10)
+	b.SetOverflowPolicy(OverflowError)
+	if err := p.ApplyDeltas(map[*Bar]int{a: 3, b: 11}); err != ErrMaxCurrentReached {
+		t.Fatal("want", ErrMaxCurrentReached, "got", err)
+	}
+	if a.Current() != 3 || b.Current() != 0 {
+		t.Fatal("want", "3 and 0", "got", a.Current(), b.Current())
+	}
+
+	p.Update(func(bars []*Bar) {
+		for _, bar := range bars {
+			bar.Set(10)
+		}
+	})
+	if !a.Completed() || !b.Completed() {
+		t.Fatal("want", "all bars completed")
+	}
+}
+
+func TestApplyDeltasOnComplete(t *testing.T) {
+	p := New()
+	a, b := p.AddBar(10), p.AddBar(10)
+	a.OnComplete(func(bar *Bar) {
+		p.RemoveBar(bar)
+	})
+	if err := p.ApplyDeltas(map[*Bar]int{a: 10, b: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Bars) != 1 || p.Bars[0] != b {
+		t.Fatal("want", "the completed bar removed by its callback", "got", len(p.Bars))
+	}
+}
@@ -0,0 +1,119 @@
+package uiprogress
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// Update is a frame of a progress container published by a Broadcaster
+type Update struct {
+	Time time.Time
+
+	// Lines are the rendered bars without ANSI escape sequences
+	Lines []string
+
+	// Bars are the states of the bars of the frame
+	Bars []BarState
+}
+
+// Broadcaster streams the frames of progress containers to any number of subscribers, for example to mirror the
+// terminal progress in a browser
+type Broadcaster struct {
+	mtx    *sync.Mutex
+	subs   map[chan Update]struct{}
+	last   *Update
+	closed bool
+}
+
+// NewBroadcaster returns a broadcaster without subscribers
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{mtx: &sync.Mutex{}, subs: make(map[chan Update]struct{})}
+}
+
+// Subscribe returns a channel receiving the updates published from now on, starting with the latest one, and a
+// function to cancel the subscription. A subscriber that doesn't keep up only misses intermediate updates, the
+// channel holds the latest one. The channel is closed on cancel and when the broadcaster is closed.
+func (b *Broadcaster) Subscribe() (<-chan Update, func()) {
+	ch := make(chan Update, 1)
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.last != nil {
+		ch <- *b.last
+	}
+	b.subs[ch] = struct{}{}
+
+	once := &sync.Once{}
+	return ch, func() {
+		once.Do(func() {
+			b.mtx.Lock()
+			defer b.mtx.Unlock()
+			if _, ok := b.subs[ch]; ok {
+				delete(b.subs, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// Publish sends u to the subscribers without blocking, replacing the update a slow subscriber hasn't received yet
+func (b *Broadcaster) Publish(u Update) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.closed {
+		return
+	}
+	b.last = &u
+	for ch := range b.subs {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- u
+	}
+}
+
+// Close closes the channels of the subscribers. Updates published afterwards are dropped.
+func (b *Broadcaster) Close() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// Broadcast publishes each frame the render loop renders to b, in addition to rendering it
+func (p *Progress) Broadcast(b *Broadcaster) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.broadcasters = append(p.broadcasters, b)
+}
+
+// broadcast publishes the frame render just drew to the broadcasters. The caller must hold the lock.
+func (p *Progress) broadcast() {
+	if len(p.broadcasters) == 0 {
+		return
+	}
+	lines := p.frame
+	if lines == nil {
+		// the plain and accessible modes don't draw the lines of the bars
+		lines = p.lines()
+	}
+	u := Update{Time: p.clock.Now(), Bars: make([]BarState, len(p.Bars))}
+	for _, line := range lines {
+		u.Lines = append(u.Lines, strutil.StripANSI(line))
+	}
+	for i, bar := range p.Bars {
+		u.Bars[i] = bar.State()
+	}
+	for _, b := range p.broadcasters {
+		b.Publish(u)
+	}
+}
@@ -0,0 +1,59 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBroadcast(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.lw.setOut(out)
+	b := NewBroadcaster()
+	p.Broadcast(b)
+
+	updates, cancel := b.Subscribe()
+	p.Bars[0].Set(5)
+	p.tick(nil)
+	p.Bars[0].Set(6)
+	p.tick(nil)
+
+	// the slow subscriber only gets the latest update
+	u := <-updates
+	if len(u.Lines) != 1 || !strings.HasPrefix(u.Lines[0], "[") || u.Bars[0].Current != 6 {
+		t.Fatal("want", "the frame of the bar at 6", "got", u)
+	}
+
+	late, cancelLate := b.Subscribe()
+	if u := <-late; u.Bars[0].Current != 6 {
+		t.Fatal("want", "the latest update on subscribe", "got", u)
+	}
+	cancelLate()
+	if _, ok := <-late; ok {
+		t.Fatal("want channel closed on cancel")
+	}
+
+	b.Close()
+	if _, ok := <-updates; ok {
+		t.Fatal("want channel closed on close")
+	}
+	cancel()
+}
+
+func TestBroadcastRendersOnce(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.lw.setOut(out)
+	p.Broadcast(NewBroadcaster())
+	calls := 0
+	p.Bars[0].SetTotal(0)
+	p.Bars[0].AppendFunc(func(*Bar) string {
+		calls++
+		return "spinning"
+	})
+
+	p.tick(nil)
+	if calls != 1 {
+		t.Fatal("want", "the bar rendered once per frame", "got", calls)
+	}
+}
@@ -0,0 +1,56 @@
+package uiprogress
+
+import "sync/atomic"
+
+// SetRenderBudget limits the number of bars whose line is rebuilt per frame to n, for containers with so many bars
+// that rebuilding all the changed ones takes longer than the refresh interval. The other changed bars keep the line
+// they were last rendered with and are rebuilt in the following frames, round-robin, so every line is a complete
+// rendering of its bar and each bar catches up within a few frames. The final frame drawn by Stop rebuilds all the
+// bars. An n of 0 rebuilds every changed bar on each frame.
+func (p *Progress) SetRenderBudget(n int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.renderBudget = n
+}
+
+// holdBars lets the changed bars within the render budget be rebuilt, starting from budgetNext, and holds the others
+// with their previous rendering. The caller must hold the lock.
+func (p *Progress) holdBars() {
+	n := len(p.Bars)
+	if n == 0 {
+		return
+	}
+	start := p.budgetNext % n
+	rebuilt := 0
+	for i := 0; i < n; i++ {
+		bar := p.Bars[(start+i)%n]
+		hold := rebuilt >= p.renderBudget
+		if !hold && bar.stale() {
+			rebuilt++
+			p.budgetNext = (start + i + 1) % n
+		}
+		bar.setHeld(hold)
+	}
+}
+
+// releaseBars lets the bars held by holdBars be rebuilt again. The caller must hold the lock.
+func (p *Progress) releaseBars() {
+	for _, bar := range p.Bars {
+		bar.setHeld(false)
+	}
+}
+
+// stale reports whether the rendering of the bar is out of date
+func (b *Bar) stale() bool {
+	key := b.renderKey()
+	return key.version == 0 || key != b.cached().key
+}
+
+// setHeld sets whether the bar keeps its previous rendering
+func (b *Bar) setHeld(held bool) {
+	var n int32
+	if held {
+		n = 1
+	}
+	atomic.StoreInt32(&b.held, n)
+}
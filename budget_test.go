@@ -0,0 +1,111 @@
+package uiprogress
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+// renderFrame renders a frame of p and returns its lines
+func renderFrame(p *Progress) []string {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.render()
+	p.broadcast()
+	return p.frame
+}
+
+func newBudgetProgress(budget int) *Progress {
+	out := &syncBuffer{}
+	p := New()
+	p.Out = out
+	p.lw.setOut(out)
+	p.Width = 12
+	for i := 0; i < 5; i++ {
+		p.AddBar(10)
+	}
+	p.SetRenderBudget(budget)
+	return p
+}
+
+func TestSetRenderBudget(t *testing.T) {
+	p := newBudgetProgress(2)
+	empty := renderFrame(p)[0]
+	for _, bar := range p.Bars {
+		bar.Set(10)
+	}
+
+	for frame, want := range []int{2, 4, 5, 5} {
+		done := 0
+		for i, line := range renderFrame(p) {
+			switch line {
+			case string(p.Bars[i].Bytes()):
+				done++
+			case empty:
+			default:
+				t.Fatal("want", "complete renderings", "got", line)
+			}
+		}
+		if done != want {
+			t.Fatal("want", want, "bars rebuilt by frame", frame, "got", done)
+		}
+	}
+}
+
+func TestRenderBudgetBroadcast(t *testing.T) {
+	p := newBudgetProgress(2)
+	b := NewBroadcaster()
+	p.Broadcast(b)
+	updates, cancel := b.Subscribe()
+	defer cancel()
+	renderFrame(p)
+	<-updates
+	for _, bar := range p.Bars {
+		bar.Set(10)
+	}
+
+	for frame, want := range []int{2, 4, 5} {
+		lines := renderFrame(p)
+		u := <-updates
+		if fmt.Sprint(u.Lines) != fmt.Sprint(lines) {
+			t.Fatal("want", "the lines drawn broadcast in frame", frame, lines, "got", u.Lines)
+		}
+		done := 0
+		for i, line := range lines {
+			if line == string(p.Bars[i].Bytes()) {
+				done++
+			}
+		}
+		if done != want {
+			t.Fatal("want", want, "bars rebuilt by frame", frame, "got", done)
+		}
+	}
+}
+
+func BenchmarkRenderBudget(b *testing.B) {
+	for _, budget := range []int{0, 500} {
+		b.Run(fmt.Sprintf("budget=%d", budget), func(b *testing.B) {
+			totals := make([]int, 10000)
+			for i := range totals {
+				totals[i] = 1 << 30
+			}
+			p := New()
+			p.Out = ioutil.Discard
+			p.lw.setOut(ioutil.Discard)
+			bars := p.AddBars(totals)
+			for _, bar := range bars {
+				bar.AppendCompleted().PrependElapsed()
+			}
+			p.SetRenderBudget(budget)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for _, bar := range bars {
+					bar.Incr()
+				}
+				b.StartTimer()
+				renderFrame(p)
+			}
+		})
+	}
+}
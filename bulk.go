@@ -0,0 +1,38 @@
+package uiprogress
+
+// AddBars creates a bar for each of the totals and adds them to the default progress container, see Progress.AddBars
+func AddBars(totals []int, opts ...BarOption) []*Bar {
+	return defaultProgress.AddBars(totals, opts...)
+}
+
+// AddBars creates a bar for each of the totals, configured with the options, and adds them to the container at once.
+// Registering thousands of bars this way takes the lock and grows Bars once, instead of once per bar.
+func (p *Progress) AddBars(totals []int, opts ...BarOption) []*Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.grow(len(totals))
+	bars := make([]*Bar, len(totals))
+	for i, total := range totals {
+		bars[i] = p.newBar(int64(total), opts)
+		p.addBar(bars[i])
+	}
+	return bars
+}
+
+// Grow makes room in the container for n more bars, so adding them doesn't reallocate Bars
+func (p *Progress) Grow(n int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.grow(n)
+}
+
+// grow makes room in Bars for n more bars. The caller must hold the lock.
+func (p *Progress) grow(n int) {
+	if n <= cap(p.Bars)-len(p.Bars) {
+		return
+	}
+	bars := make([]*Bar, len(p.Bars), len(p.Bars)+n)
+	copy(bars, p.Bars)
+	p.Bars = bars
+}
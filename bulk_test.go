@@ -0,0 +1,32 @@
+package uiprogress
+
+import "testing"
+
+func TestAddBars(t *testing.T) {
+	p := New()
+	p.AddBar(1)
+	bars := p.AddBars([]int{10, 20, 30}, WithWidth(12))
+	if len(bars) != 3 || len(p.Bars) != 4 {
+		t.Fatal("want", "3 bars added to 1", "got", len(bars), len(p.Bars))
+	}
+	for i, bar := range bars {
+		if bar.Total != 10*(i+1) || bar.Width != 12 || bar.ID() != i+1 || p.Bars[i+1] != bar {
+			t.Fatal("want", "bars in order with their totals", "got", bar.ID(), bar.Total, bar.Width)
+		}
+	}
+
+	p.Grow(100)
+	if got := cap(p.Bars) - len(p.Bars); got < 100 {
+		t.Fatal("want", 100, "got", got)
+	}
+}
+
+func BenchmarkAddBars(b *testing.B) {
+	totals := make([]int, 10000)
+	for i := range totals {
+		totals[i] = 100
+	}
+	for i := 0; i < b.N; i++ {
+		New().AddBars(totals)
+	}
+}
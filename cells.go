@@ -0,0 +1,72 @@
+package uiprogress
+
+import "github.com/gosuri/uiprogress/util/strutil"
+
+// Style identifies the part of the bar a rendered cell belongs to
+type Style int
+
+const (
+	// StyleText is the style of the decorator text around the progress indicator
+	StyleText Style = iota
+
+	// StyleLeftEnd is the style of the left most part of the progress indicator
+	StyleLeftEnd
+
+	// StyleRightEnd is the style of the right most part of the progress indicator
+	StyleRightEnd
+
+	// StyleFill is the style of the completed progress
+	StyleFill
+
+	// StyleHead is the style of the character that moves when progress is updated
+	StyleHead
+
+	// StyleEmpty is the style of the empty progress
+	StyleEmpty
+)
+
+// Cell is a single column of a rendered bar, for screen buffer based UIs such as tcell or termbox. A wide rune is
+// followed by a cell of rune 0 for the second column it takes.
+type Cell struct {
+	Rune  rune
+	Style Style
+}
+
+// RenderCells returns the bar as a cell per column with a progress indicator of the given width, or filling the line for
+// WidthAuto, instead of a string. The bar is laid out like Bytes lays it out, without the ANSI escape sequences. The
+// cells can be placed into a screen buffer at any coordinates.
+func (b *Bar) RenderCells(width int) []Cell {
+	var cells []Cell
+	for _, part := range b.lineParts(width) {
+		if part.track == nil {
+			cells = appendText(cells, part.text)
+			continue
+		}
+		// the rendered track has a rune for each column of the track, with the marks and segments of the bar
+		for i, r := range []rune(strutil.StripANSI(string(b.renderTrack(part.track)))) {
+			if i < len(part.track) {
+				cells = appendCell(cells, r, part.track[i])
+			}
+		}
+	}
+	return cells
+}
+
+// appendText appends the cells of the runes of s, without its ANSI escape sequences
+func appendText(cells []Cell, s string) []Cell {
+	for _, r := range strutil.StripANSI(s) {
+		cells = appendCell(cells, r, StyleText)
+	}
+	return cells
+}
+
+// appendCell appends a cell per column r takes, none for zero width runes
+func appendCell(cells []Cell, r rune, style Style) []Cell {
+	switch strutil.RuneWidth(r) {
+	case 0:
+		return cells
+	case 2:
+		return append(cells, Cell{Rune: r, Style: style}, Cell{Style: style})
+	}
+	return append(cells, Cell{Rune: r, Style: style})
+}
@@ -0,0 +1,66 @@
+package uiprogress
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+func TestRenderCells(t *testing.T) {
+	b := NewBar(100).AppendCompleted()
+	b.Set(50)
+
+	cells := b.RenderCells(10)
+	want := []Cell{
+		{'[', StyleLeftEnd},
+		{'=', StyleFill}, {'=', StyleFill}, {'=', StyleFill}, {'>', StyleHead},
+		{'-', StyleEmpty}, {'-', StyleEmpty}, {'-', StyleEmpty}, {'-', StyleEmpty},
+		{']', StyleRightEnd},
+		{' ', StyleText}, {' ', StyleText}, {'5', StyleText}, {'0', StyleText}, {'%', StyleText},
+	}
+	if len(cells) != len(want) {
+		t.Fatal("want", len(want), "cells", "got", len(cells), cells)
+	}
+	for i := range want {
+		if cells[i] != want[i] {
+			t.Fatal("want", want[i], "at", i, "got", cells[i])
+		}
+	}
+}
+
+func TestRenderCellsLayout(t *testing.T) {
+	tpl := NewBar(10)
+	if err := tpl.SetTemplate("{{.Current}} [{{.Bar}}] done"); err != nil {
+		t.Fatal(err)
+	}
+	cols := NewBar(10).SetName("cols").SetColumns(TextColumn(BarName(), 6, AlignLeft), BarColumn())
+	failed := NewBar(10).AppendCompleted().SetError(errors.New("boom"))
+	wide := NewBar(10).PrependFunc(func(*Bar) string { return "名前" })
+
+	for _, b := range []*Bar{tpl, cols, failed, wide} {
+		b.Width = 8
+		b.Set(5)
+		cells := b.RenderCells(8)
+		want := strutil.StripANSI(b.String())
+		if len(cells) != strutil.Width(want) {
+			t.Fatal("want", strutil.Width(want), "cells", "got", len(cells), cells)
+		}
+		var got []rune
+		for _, c := range cells {
+			if c.Rune != 0 {
+				got = append(got, c.Rune)
+			}
+		}
+		if string(got) != want {
+			t.Fatalf("want %q got %q", want, string(got))
+		}
+	}
+
+	if cells := tpl.RenderCells(8); cells[2] != (Cell{'[', StyleText}) || cells[3].Style != StyleFill {
+		t.Fatal("want", "the track of the template styled", "got", cells[:5])
+	}
+	if cells := wide.RenderCells(8); cells[0].Rune != '名' || cells[1].Rune != 0 || cells[2].Rune != '前' {
+		t.Fatal("want", "a filler cell after each wide rune", "got", cells[:4])
+	}
+}
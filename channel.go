@@ -0,0 +1,23 @@
+package uiprogress
+
+// ListenOn sets the current value of the bar to each count received on ch, until ch is closed, so producers can
+// report progress without a reference to the bar
+func (b *Bar) ListenOn(ch <-chan int) *Bar {
+	go func() {
+		for n := range ch {
+			b.Set(n)
+		}
+	}()
+	return b
+}
+
+// ListenOnDelta increments the bar by each count received on ch, until ch is closed. Several producers can send
+// the progress they made on the same channel.
+func (b *Bar) ListenOnDelta(ch <-chan int) *Bar {
+	go func() {
+		for n := range ch {
+			b.IncrBy(n)
+		}
+	}()
+	return b
+}
@@ -0,0 +1,20 @@
+package uiprogress
+
+import "testing"
+
+func TestListenOn(t *testing.T) {
+	b := NewBar(100)
+	ch := make(chan int)
+	b.ListenOn(ch)
+	ch <- 30
+	ch <- 40
+	close(ch)
+	waitFor(t, func() bool { return b.Current() == 40 })
+
+	deltas := make(chan int)
+	b.ListenOnDelta(deltas)
+	for i := 0; i < 3; i++ {
+		go func() { deltas <- 10 }()
+	}
+	waitFor(t, func() bool { return b.Current() == 70 })
+}
@@ -0,0 +1,71 @@
+package uiprogress
+
+import "time"
+
+// Clock is the source of time of bars and progress containers, replaceable for deterministic tests of the time
+// elapsed, the ETA and the refresh scheduling
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers the ticks of a Clock on C until stopped
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// realClock is the clock backed by the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+// realTicker is the ticker backed by the time package
+type realTicker struct {
+	t *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+
+func (t realTicker) Stop() { t.t.Stop() }
+
+// SetClock sets the clock the bar measures the time elapsed with, the system clock when c is nil
+func (b *Bar) SetClock(c Clock) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.clock = c
+	return b
+}
+
+// timeSource returns the clock of the bar. The caller must hold the lock.
+func (b *Bar) timeSource() Clock {
+	if b.clock == nil {
+		return realClock{}
+	}
+	return b.clock
+}
+
+// SetClock sets the clock of the container and of its bars, including the ones added later. A nil c restores the
+// system clock.
+func (p *Progress) SetClock(c Clock) {
+	if c == nil {
+		c = realClock{}
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.clock = c
+	for _, bar := range p.Bars {
+		bar.SetClock(c)
+	}
+}
@@ -0,0 +1,34 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBarClock(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	b := NewBar(10).SetClock(clk)
+	b.Incr()
+	clk.Sleep(time.Second * 2)
+	b.IncrBy(4)
+	if got := b.TimeElapsed(); got != time.Second*2 {
+		t.Fatal("want", time.Second*2, "got", got)
+	}
+	if got := b.ETA(); got != time.Millisecond*2500 {
+		t.Fatal("want", time.Millisecond*2500, "got", got)
+	}
+}
+
+func TestProgressClock(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	before := p.AddBar(10)
+	p.SetClock(clk)
+	after := p.AddBar(10)
+	for _, b := range []*Bar{before, after} {
+		b.Incr()
+		if !b.TimeStarted.Equal(clk.now) {
+			t.Fatal("want", clk.now, "got", b.TimeStarted)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package uiprogress
+
+// AddBarFromTemplate creates a bar configured like tpl and adds it to the default progress container, see
+// Progress.AddBarFromTemplate
+func AddBarFromTemplate(tpl *Bar, total int, opts ...BarOption) *Bar {
+	return defaultProgress.AddBarFromTemplate(tpl, total, opts...)
+}
+
+// AddBarFromTemplate creates a bar of the given total configured like tpl, with its characters, width, theme,
+// decorators and completion settings, and adds it to the container. A template is usually a bar created with NewBar
+// and never added, configured once to stamp out many identical bars. The options are applied after the template.
+func (p *Progress) AddBarFromTemplate(tpl *Bar, total int, opts ...BarOption) *Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	bar := p.newBar(int64(total), append([]BarOption{WithBarTemplate(tpl)}, opts...))
+	p.addBar(bar)
+	return bar
+}
+
+// WithBarTemplate configures the bar like tpl, see Progress.AddBarFromTemplate. The progress and name of tpl are not
+// copied. The width and columns of tpl are only copied when they were set, leaving the bar those of its container
+// otherwise.
+func WithBarTemplate(tpl *Bar) BarOption {
+	return func(b *Bar) {
+		tpl.mtx.RLock()
+		defer tpl.mtx.RUnlock()
+
+		b.LeftEnd, b.RightEnd, b.Fill, b.Head, b.Empty = tpl.LeftEnd, tpl.RightEnd, tpl.Fill, tpl.Head, tpl.Empty
+		if tpl.Width != Width {
+			b.Width = tpl.Width
+		}
+		b.OnCompleteStyle, b.CompleteMessage = tpl.OnCompleteStyle, tpl.CompleteMessage
+		b.Wrap = tpl.Wrap
+		if tpl.theme != nil {
+			theme := *tpl.theme
+			b.theme = &theme
+		}
+		b.colorFunc = tpl.colorFunc
+		b.unit, b.units = tpl.unit, tpl.units
+		b.percentPrecision, b.percentFormat = tpl.percentPrecision, tpl.percentFormat
+		b.overflow = tpl.overflow
+		b.ellipsis = tpl.ellipsis
+		b.stallTimeout, b.onStall = tpl.stallTimeout, tpl.onStall
+		b.tpl = tpl.tpl
+		if tpl.columns != nil {
+			b.columns = append([]Column(nil), tpl.columns...)
+		}
+		b.decorators.Store(tpl.funcs())
+		b.completeFuncs = append([]func(b *Bar){}, tpl.completeFuncs...)
+	}
+}
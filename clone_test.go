@@ -0,0 +1,43 @@
+package uiprogress
+
+import "testing"
+
+func TestAddBarFromTemplate(t *testing.T) {
+	tpl := NewBar(0)
+	tpl.Width = 12
+	tpl.Fill = '#'
+	tpl.AppendCompleted()
+	tpl.SetOnCompleteStyle(CompleteReplace, "done")
+
+	p := New()
+	a := p.AddBarFromTemplate(tpl, 10)
+	b := p.AddBarFromTemplate(tpl, 4, WithName("b"))
+	a.Set(5)
+	b.Set(2)
+	if got, want := a.String(), "[####>-----]  50%"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	if b.String() != a.String() || b.Name() != "b" {
+		t.Fatal("want", "identical bars", "got", b.String(), b.Name())
+	}
+
+	// the bars don't share their decorators with the template
+	a.AppendFunc(func(*Bar) string { return "a" })
+	if got := len(tpl.funcs().appends); got != 1 {
+		t.Fatal("want", 1, "got", got)
+	}
+}
+
+func TestAddBarFromTemplateKeepsContainerLayout(t *testing.T) {
+	tpl := NewBar(0).AppendCompleted()
+	p := New()
+	p.Width = 20
+	p.SetColumns(TextColumn(BarName(), 4, AlignLeft), BarColumn())
+	bar := p.AddBarFromTemplate(tpl, 10, WithName("a"))
+	if bar.Width != 20 || len(bar.layout()) != 2 {
+		t.Fatal("want", "the width and columns of the container", "got", bar.Width, bar.layout())
+	}
+	if got := bar.String(); len(got) != len("a    ")+20 {
+		t.Fatal("want", "the bar laid out in the columns", "got", got)
+	}
+}
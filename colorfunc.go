@@ -0,0 +1,45 @@
+package uiprogress
+
+// ColorFunc returns the color of the fill of a bar completed at percent
+type ColorFunc func(percent float64) Color
+
+// Threshold is a completion percent below which the fill of a bar has Color
+type Threshold struct {
+	Below float64
+	Color Color
+}
+
+// ThresholdColors returns a ColorFunc picking the color of the first of the thresholds, in ascending order, the percent
+// is below, and above when it is below none
+func ThresholdColors(above Color, thresholds ...Threshold) ColorFunc {
+	return func(percent float64) Color {
+		for _, t := range thresholds {
+			if percent < t.Below {
+				return t.Color
+			}
+		}
+		return above
+	}
+}
+
+// TrafficLight returns a ColorFunc coloring the fill red below 25%, yellow below 75% and green above
+func TrafficLight() ColorFunc {
+	return ThresholdColors(ColorGreen, Threshold{25, ColorRed}, Threshold{75, ColorYellow})
+}
+
+// SetColorFunc colors the fill of the bar with the color f returns for its completion on each render, overriding the
+// fill and head colors of its theme. A failed bar is still rendered in red. A nil f restores the colors of the theme.
+func (b *Bar) SetColorFunc(f ColorFunc) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.colorFunc = f
+	b.markDirty()
+	return b
+}
+
+// fillColor returns the color function of the bar
+func (b *Bar) fillColor() ColorFunc {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.colorFunc
+}
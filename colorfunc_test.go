@@ -0,0 +1,26 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetColorFunc(t *testing.T) {
+	bar := NewBar(100)
+	bar.Width = 22
+	bar.SetColorFunc(TrafficLight())
+	for _, tt := range []struct {
+		current int
+		want    Color
+	}{{10, ColorRed}, {50, ColorYellow}, {90, ColorGreen}} {
+		bar.Set(tt.current)
+		if got := bar.String(); !strings.Contains(got, string(tt.want)) {
+			t.Fatalf("want the fill in %q at %d%%, got %q", tt.want, tt.current, got)
+		}
+	}
+
+	bar.SetColorFunc(nil)
+	if got := bar.String(); strings.Contains(got, "\x1b") {
+		t.Fatalf("want the colors of the theme, got %q", got)
+	}
+}
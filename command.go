@@ -0,0 +1,102 @@
+package uiprogress
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxCommandLine bounds the unterminated output of a command buffered while looking for its progress
+const maxCommandLine = 64 * 1024
+
+// FromCommand runs cmd with its progress shown on a bar of the default progress container, see Progress.FromCommand
+func FromCommand(cmd *exec.Cmd, pattern *regexp.Regexp, group int, opts ...BarOption) error {
+	return defaultProgress.FromCommand(cmd, pattern, group, opts...)
+}
+
+// FromCommand runs cmd and waits for it to exit, showing its progress on a bar named after the command. Each line
+// the command prints on stdout or stderr, split on carriage returns too, is matched against pattern and the group
+// capture, a percent such as "45" or "45.2%", sets the completion of the bar. The output is still written to
+// cmd.Stdout and cmd.Stderr when set. The bar completes when the command succeeds and fails with its error otherwise.
+func (p *Progress) FromCommand(cmd *exec.Cmd, pattern *regexp.Regexp, group int, opts ...BarOption) error {
+	name := filepath.Base(cmd.Path)
+	if len(cmd.Args) > 0 {
+		name = filepath.Base(cmd.Args[0])
+	}
+	bar := p.AddBar64(funcBarTotal, append([]BarOption{WithName(name)}, opts...)...)
+
+	mtx := &sync.Mutex{}
+	cmd.Stdout = &commandWriter{out: cmd.Stdout, bar: bar, pattern: pattern, group: group, mtx: mtx}
+	cmd.Stderr = &commandWriter{out: cmd.Stderr, bar: bar, pattern: pattern, group: group, mtx: mtx}
+	if err := cmd.Run(); err != nil {
+		bar.SetError(err)
+		return err
+	}
+	bar.Set64(funcBarTotal)
+	return nil
+}
+
+// commandWriter matches the lines of the output of a command for its progress
+type commandWriter struct {
+	out     io.Writer
+	bar     *Bar
+	pattern *regexp.Regexp
+	group   int
+
+	// mtx is shared by the writers of a command, which may pass both streams to the same writer
+	mtx  *sync.Mutex
+	line []byte
+}
+
+// Write writes buf to the output of the command and sets the bar from the last progress in it
+func (w *commandWriter) Write(buf []byte) (int, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.out != nil {
+		if n, err := w.out.Write(buf); err != nil {
+			return n, err
+		}
+	}
+	w.line = append(w.line, buf...)
+	if i := bytes.LastIndexAny(w.line, "\r\n"); i >= 0 {
+		w.match(w.line[:i])
+		w.line = append(w.line[:0], w.line[i+1:]...)
+	}
+	if len(w.line) > maxCommandLine {
+		w.line = append(w.line[:0], w.line[len(w.line)-maxCommandLine:]...)
+	}
+	// progress is often printed without a line ending until it is overwritten
+	w.match(w.line)
+	return len(buf), nil
+}
+
+// match sets the bar from the last line of out matching the pattern
+func (w *commandWriter) match(out []byte) {
+	lines := bytes.FieldsFunc(out, func(r rune) bool {
+		return r == '\r' || r == '\n'
+	})
+	for i := len(lines) - 1; i >= 0; i-- {
+		m := w.pattern.FindSubmatch(lines[i])
+		if w.group >= len(m) {
+			continue
+		}
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(string(m[w.group])), "%"), 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case pct < 0:
+			pct = 0
+		case pct > 100:
+			pct = 100
+		}
+		w.bar.Set64(int64(pct / 100 * funcBarTotal))
+		return
+	}
+}
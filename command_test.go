@@ -0,0 +1,64 @@
+package uiprogress
+
+import (
+	"bytes"
+	"os/exec"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestCommandWriter(t *testing.T) {
+	var out bytes.Buffer
+	bar := NewBar(funcBarTotal)
+	w := &commandWriter{out: &out, bar: bar, pattern: regexp.MustCompile(`(\d+(\.\d+)?)%`), group: 1, mtx: &sync.Mutex{}}
+
+	w.Write([]byte("sending incremental file list\r  1,024  12%"))
+	if got := bar.Current64(); got != 1200 {
+		t.Fatal("want", 1200, "got", got)
+	}
+	w.Write([]byte("\r  4,096  4"))
+	if got := bar.Current64(); got != 1200 {
+		t.Fatal("want unterminated progress matched once complete, got", got)
+	}
+	w.Write([]byte("5.5%\n"))
+	if got := bar.Current64(); got != 4550 {
+		t.Fatal("want", 4550, "got", got)
+	}
+	if got := out.String(); got != "sending incremental file list\r  1,024  12%\r  4,096  45.5%\n" {
+		t.Fatal("want output passed through, got", got)
+	}
+}
+
+func TestFromCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell")
+	}
+	p := New()
+	pattern := regexp.MustCompile(`(\d+)%`)
+
+	var out bytes.Buffer
+	cmd := exec.Command("sh", "-c", `printf '10%%\r50%%\r'; echo done; printf '90%%\n' >&2`)
+	cmd.Stdout = &out
+	if err := p.FromCommand(cmd, pattern, 1); err != nil {
+		t.Fatal(err)
+	}
+	bar := p.Bars[0]
+	if !bar.completed() || bar.Err() != nil {
+		t.Fatal("want completed bar, got", bar.Current64(), bar.Err())
+	}
+	if got := bar.Name(); got != "sh" {
+		t.Fatal("want", "sh", "got", got)
+	}
+	if got := out.String(); got != "10%\r50%\rdone\n" {
+		t.Fatal("want stdout passed through, got", got)
+	}
+
+	if err := p.FromCommand(exec.Command("sh", "-c", "echo 30%; exit 3"), pattern, 1); err == nil {
+		t.Fatal("want error")
+	}
+	bar = p.Bars[1]
+	if bar.Err() == nil || bar.Current64() != 3000 {
+		t.Fatal("want failed bar at 30%, got", bar.Current64(), bar.Err())
+	}
+}
@@ -0,0 +1,82 @@
+package uiprogress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// CompactWidth is the terminal width below which a progress container renders its bars compact, as their name and
+// completed percent only, so the lines don't wrap. A CompactWidth of 0 disables compact rendering.
+var CompactWidth = 30
+
+// SummaryWidth is the width of the track of the summary line rendered in compact mode
+var SummaryWidth = 20
+
+// CompactString returns the name, the completed percent and the failure of the bar, for example "download 42%"
+func (b *Bar) CompactString() string {
+	parts := []string{strings.TrimSpace(b.CompletedPercentString())}
+	if name := b.Name(); name != "" {
+		parts = append([]string{name}, parts...)
+	}
+	if failure := b.ErrorString(); failure != "" {
+		parts = append(parts, ColorRed.Paint(failure))
+	}
+	return strings.Join(parts, " ")
+}
+
+// SetCompact renders the container as a single summary line of its bars, like "[3/10 done] ████░░ 42% ETA 1m2s",
+// instead of a line per bar, for tools that want a minimal footprint. It can be turned on and off while rendering.
+func (p *Progress) SetCompact(on bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.compact = on
+	p.dirty = true
+	p.notifyChange()
+}
+
+// SummaryLine returns the summary line rendered in compact mode: the number of bars done, the combined completion of
+// the bars with a total and the longest ETA of the bars not done
+func (p *Progress) SummaryLine() string {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.summaryLine()
+}
+
+// summaryLine returns the summary line of the bars. The caller must hold the lock.
+func (p *Progress) summaryLine() string {
+	var done, count int
+	var current, total int64
+	var eta time.Duration
+	for _, bar := range p.Bars {
+		bar.mtx.RLock()
+		isOverall, n, max := bar.overall, bar.current, bar.Total64()
+		bar.mtx.RUnlock()
+		if isOverall {
+			continue
+		}
+		count++
+		if bar.Completed() {
+			done++
+		} else if d := bar.ETA(); d > eta {
+			eta = d
+		}
+		if max > 0 {
+			if n > max {
+				n = max
+			}
+			current += n
+			total += max
+		}
+	}
+
+	var pct float64
+	if total > 0 {
+		pct = float64(current) / float64(total) * 100
+	}
+	fill := int(pct / 100 * float64(SummaryWidth))
+	track := strings.Repeat("█", fill) + strings.Repeat("░", SummaryWidth-fill)
+	return fmt.Sprintf("[%d/%d done] %s %.f%% ETA %s", done, count, track, pct, strutil.PrettyTime(eta))
+}
@@ -0,0 +1,45 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompactWidth(t *testing.T) {
+	p := New()
+	cols := 20
+	p.Sizer = TerminalSizerFunc(func() (int, int, error) { return cols, 10, nil })
+	bar := p.AddNamedBar("download", 100)
+	bar.Set(42)
+	p.ChangeWidth()
+	if bar.Width != MinWidth {
+		t.Fatal("want", MinWidth, "got", bar.Width)
+	}
+	if got := p.Lines()[0]; got != "download 42%" {
+		t.Fatal("want", "download 42%", "got", got)
+	}
+
+	cols = 80
+	p.ChangeWidth()
+	if got := p.Lines()[0]; got == "download 42%" {
+		t.Fatal("want", "the full bar", "got", got)
+	}
+}
+
+func TestSetCompact(t *testing.T) {
+	p := New()
+	p.AddBar(10).Set(10)
+	p.AddBar(10).Set(5)
+	p.AddBar(20)
+	p.AddOverallBar()
+	p.SetCompact(true)
+
+	lines := p.Lines()
+	if want := "[1/3 done] " + strings.Repeat("█", 7) + strings.Repeat("░", 13) + " 38% ETA ---"; len(lines) != 1 || lines[0] != want {
+		t.Fatal("want", want, "got", lines)
+	}
+	p.SetCompact(false)
+	if got := len(p.Lines()); got != 4 {
+		t.Fatal("want", 4, "got", got)
+	}
+}
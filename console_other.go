@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import "io"
+
+// enableANSI is a no-op on platforms where terminals interpret ANSI escape sequences natively
+func enableANSI(w io.Writer) (restore func(), ok bool) {
+	return func() {}, true
+}
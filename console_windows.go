@@ -0,0 +1,59 @@
+package uiprogress
+
+import (
+	"io"
+	"syscall"
+	"unsafe"
+)
+
+// enableVirtualTerminalProcessing is the console mode flag that makes the console interpret ANSI escape sequences
+const enableVirtualTerminalProcessing = 0x0004
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// getConsoleMode and setConsoleMode wrap the console API and are variables so they can be replaced in tests
+var (
+	getConsoleMode = func(h syscall.Handle) (uint32, error) {
+		var mode uint32
+		r, _, err := procGetConsoleMode.Call(uintptr(h), uintptr(unsafe.Pointer(&mode)))
+		if r == 0 {
+			return 0, err
+		}
+		return mode, nil
+	}
+
+	setConsoleMode = func(h syscall.Handle, mode uint32) error {
+		r, _, err := procSetConsoleMode.Call(uintptr(h), uintptr(mode))
+		if r == 0 {
+			return err
+		}
+		return nil
+	}
+)
+
+// enableANSI turns on virtual terminal processing for the console behind w. It returns a function restoring the
+// original console mode and false when the console cannot interpret ANSI escape sequences.
+func enableANSI(w io.Writer) (restore func(), ok bool) {
+	restore = func() {}
+	fd, isFd := fdOf(w)
+	if !isFd {
+		return restore, true
+	}
+	h := syscall.Handle(fd)
+	mode, err := getConsoleMode(h)
+	if err != nil {
+		// not a console, escape sequences are passed through as is
+		return restore, true
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return restore, true
+	}
+	if err := setConsoleMode(h, mode|enableVirtualTerminalProcessing); err != nil {
+		return restore, false
+	}
+	return func() { setConsoleMode(h, mode) }, true
+}
@@ -0,0 +1,51 @@
+package uiprogress
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func mockConsoleMode(t *testing.T, setErr error) *[]uint32 {
+	origGet, origSet := getConsoleMode, setConsoleMode
+	t.Cleanup(func() { getConsoleMode, setConsoleMode = origGet, origSet })
+
+	var modes []uint32
+	getConsoleMode = func(h syscall.Handle) (uint32, error) { return 0x0003, nil }
+	setConsoleMode = func(h syscall.Handle, mode uint32) error {
+		if setErr != nil {
+			return setErr
+		}
+		modes = append(modes, mode)
+		return nil
+	}
+	return &modes
+}
+
+func TestEnableANSIRestoresMode(t *testing.T) {
+	modes := mockConsoleMode(t, nil)
+	restore, ok := enableANSI(os.Stdout)
+	if !ok {
+		t.Fatal("want", "ansi enabled")
+	}
+	restore()
+	want := []uint32{0x0003 | enableVirtualTerminalProcessing, 0x0003}
+	if len(*modes) != 2 || (*modes)[0] != want[0] || (*modes)[1] != want[1] {
+		t.Fatal("want", want, "got", *modes)
+	}
+}
+
+func TestStartFallsBackToPlain(t *testing.T) {
+	mockConsoleMode(t, errors.New("invalid parameter"))
+	p := New()
+	p.Out = os.Stdout
+	p.Start()
+	defer p.Stop()
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if !p.plain {
+		t.Fatal("want", "plain rendering when vt mode cannot be enabled")
+	}
+}
@@ -0,0 +1,60 @@
+package uiprogress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// SetCarriageReturn makes the progress redraw a single line in place with a carriage return while it renders only
+// one line, instead of moving the cursor up. Outputs that don't interpret cursor movement, like docker logs, then
+// show one updating line without artifacts. Frames of more lines are redrawn as usual.
+func (p *Progress) SetCarriageReturn(on bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if !on {
+		p.endLine()
+	}
+	p.carriageReturn = on
+	p.notifyChange()
+}
+
+// renderLine redraws line over the previous one with a carriage return. The caller must hold the lock.
+func (p *Progress) renderLine(line string) {
+	if p.lineWidth == 0 {
+		// the bars were drawn with the cursor movement until now
+		p.clearLines()
+	}
+	width := strutil.Width(line)
+	pad := ""
+	if p.lineWidth > width {
+		pad = strings.Repeat(" ", p.lineWidth-width)
+	}
+	n, _ := fmt.Fprint(p.Out, "\r"+line+pad)
+	p.stats.bytes = n
+	p.lineWidth = width
+	if p.lineWidth == 0 {
+		// an empty line is still drawn, the next frame must not clear the lines above it
+		p.lineWidth = 1
+	}
+}
+
+// eraseLine erases the line redrawn with a carriage return, reporting whether there was one.
+// The caller must hold the lock.
+func (p *Progress) eraseLine() bool {
+	if p.lineWidth == 0 {
+		return false
+	}
+	fmt.Fprint(p.Out, "\r"+strings.Repeat(" ", p.lineWidth)+"\r")
+	p.lineWidth = 0
+	return true
+}
+
+// endLine moves past the line redrawn with a carriage return, leaving it on the output. The caller must hold the lock.
+func (p *Progress) endLine() {
+	if p.lineWidth > 0 {
+		fmt.Fprintln(p.Out)
+		p.lineWidth = 0
+	}
+}
@@ -0,0 +1,34 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetCarriageReturn(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.lw.setOut(out)
+	p.SetCarriageReturn(true)
+	label := "long label"
+	bar := p.Bars[0].PrependFunc(func(*Bar) string { return label })
+	bar.Width = 4
+
+	p.mtx.Lock()
+	p.render()
+	label = "short"
+	bar.Invalidate()
+	p.render()
+	p.mtx.Unlock()
+	if got, want := out.String(), "\rlong label [--]\rshort [--]     "; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	p.AddBar(10).Width = 4
+	p.mtx.Lock()
+	p.render()
+	p.mtx.Unlock()
+	if got := out.String(); !strings.HasSuffix(got, "     \nshort [--]\n[--]\n") {
+		t.Fatalf("want the line ended before the frame, got %q", got)
+	}
+}
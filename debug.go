@@ -0,0 +1,53 @@
+package uiprogress
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// renderStats are the render diagnostics shown when Debug is set
+type renderStats struct {
+	// frames are the frames rendered since windowStarted, fps the frames per second over the last full window
+	frames        int
+	windowStarted time.Time
+	fps           float64
+
+	// bytes is the size of the last frame written to Out
+	bytes int
+
+	// versions is the sum of the bar versions at the last frame, dropped the updates that didn't get a frame
+	versions uint64
+	dropped  uint64
+}
+
+// countFrame counts a frame rendered at now into the diagnostics. The caller must hold the lock.
+func (p *Progress) countFrame(now time.Time) {
+	s := &p.stats
+	if s.windowStarted.IsZero() {
+		s.windowStarted = now
+	} else {
+		s.frames++
+	}
+	if elapsed := now.Sub(s.windowStarted); elapsed >= time.Second {
+		s.fps = float64(s.frames) / elapsed.Seconds()
+		s.frames, s.windowStarted = 0, now
+	}
+
+	var versions uint64
+	for _, bar := range p.Bars {
+		versions += atomic.LoadUint64(&bar.version)
+	}
+	if versions > s.versions+1 {
+		// the updates made since the last frame are shown in one frame
+		s.dropped += versions - s.versions - 1
+	}
+	s.versions = versions
+}
+
+// debugLine returns the line of render diagnostics. The caller must hold the lock.
+func (p *Progress) debugLine() string {
+	s := p.stats
+	return fmt.Sprintf("fps %.1f | frame %s | %d B/flush | %d dropped", s.fps, p.latency.Round(time.Microsecond),
+		s.bytes, s.dropped)
+}
@@ -0,0 +1,31 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebug(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.SetClock(clk)
+	p.RefreshInterval = time.Millisecond * 250
+	p.Debug = true
+	var lines []string
+	p.RenderFunc = func(l []string) { lines = l }
+	bar := p.AddBar(100)
+
+	for i := 0; i < 5; i++ {
+		for n := 0; n < 3; n++ {
+			bar.Incr()
+		}
+		p.tick(nil)
+	}
+	if len(lines) != 2 {
+		t.Fatal("want", "the bar and the debug line", "got", lines)
+	}
+	// the rate sample taken by each tick after the increments is an update too
+	if want := "fps 4.0 | frame 0s | 0 B/flush | 15 dropped"; lines[1] != want {
+		t.Fatal("want", want, "got", lines[1])
+	}
+}
@@ -0,0 +1,84 @@
+package uiprogress
+
+import (
+	"fmt"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// Decorator renders text that can be prepended and appended to the progress bar
+type Decorator interface {
+	Decor(b *Bar) string
+}
+
+// Decor calls f(b), so a DecoratorFunc can be used as a Decorator
+func (f DecoratorFunc) Decor(b *Bar) string {
+	return f(b)
+}
+
+// AppendDecorator renders the output of the decorator on the right of the progress bar
+func (b *Bar) AppendDecorator(d Decorator) *Bar {
+	return b.AppendFunc(d.Decor)
+}
+
+// PrependDecorator renders the output of the decorator on the left of the progress bar
+func (b *Bar) PrependDecorator(d Decorator) *Bar {
+	return b.PrependFunc(d.Decor)
+}
+
+// Percentage returns a decorator rendering the completion percent, for example " 42%"
+func Percentage() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.CompletedPercentString()
+	})
+}
+
+// Elapsed returns a decorator rendering the time elapsed since the start of the current phase, see ResetTimer
+func Elapsed() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return strutil.PadLeft(b.TimeElapsedString(), 5, ' ')
+	})
+}
+
+// ETA returns a decorator rendering the estimated time remaining until the bar completes
+func ETA() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return strutil.PadLeft(b.ETAString(), 5, ' ')
+	})
+}
+
+// Rate returns a decorator rendering the rate of units per second, for example "12.5/s"
+func Rate() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.RateString()
+	})
+}
+
+// BytesRate returns a decorator rendering the rate as bytes per second in binary units, for example "1.5 MiB/s"
+func BytesRate() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.BytesRateString()
+	})
+}
+
+// CountersKiB returns a decorator rendering the current and total as byte sizes in binary units, for example "1.5 MiB / 3.0 MiB"
+func CountersKiB() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return fmt.Sprintf("%s / %s", strutil.FormatBytes(b.Current64(), false), strutil.FormatBytes(b.Total64(), false))
+	})
+}
+
+// Name returns a decorator rendering the name
+func Name(name string) Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return name
+	})
+}
+
+// Bytes returns a decorator rendering the bytes done, the total and the rate, for example
+// "154.3 MiB / 1.2 GiB (12.5 MiB/s)", in SI units when si is set
+func Bytes(si bool) Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.BytesString(si)
+	})
+}
@@ -0,0 +1,27 @@
+package uiprogress
+
+import "testing"
+
+func TestDecoratorPipeline(t *testing.T) {
+	b := NewBar(3 << 20)
+	b.Width = 10
+	b.PrependDecorator(Name("file.bin")).AppendDecorator(Percentage()).AppendDecorator(CountersKiB())
+	b.Set(3 << 19)
+
+	want := "file.bin [===>----]  50% 1.5 MiB / 3.0 MiB"
+	if b.String() != want {
+		t.Fatalf("want %q got %q", want, b.String())
+	}
+}
+
+func TestBytesDecorator(t *testing.T) {
+	b := NewBar(1200000)
+	b.Set(154300)
+	b.rate = 12500
+	if got, want := Bytes(true).Decor(b), "154.3 kB / 1.2 MB (12.5 kB/s)"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	if got, want := b.BytesString(false), "150.7 KiB / 1.1 MiB (12.2 KiB/s)"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
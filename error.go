@@ -0,0 +1,40 @@
+package uiprogress
+
+import "errors"
+
+// ErrAborted is the error of a bar stopped with Abort
+var ErrAborted = errors.New("aborted")
+
+// SetError marks the bar as failed with err. A failed bar renders its fill in red followed by the error, and has no ETA.
+// A nil err clears the failure.
+func (b *Bar) SetError(err error) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.err = err
+	b.markDirty()
+	return b
+}
+
+// Abort marks the bar as failed with ErrAborted
+func (b *Bar) Abort() *Bar {
+	return b.SetError(ErrAborted)
+}
+
+// Err returns the error the bar failed with, or nil
+func (b *Bar) Err() error {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.err
+}
+
+// ErrorString returns the failure of the bar, for example "✗ failed: connection reset", or "" when it didn't fail
+func (b *Bar) ErrorString() string {
+	switch err := b.Err(); err {
+	case nil:
+		return ""
+	case ErrAborted:
+		return FailureMark + " aborted"
+	default:
+		return FailureMark + " failed: " + err.Error()
+	}
+}
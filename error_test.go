@@ -0,0 +1,31 @@
+package uiprogress
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetError(t *testing.T) {
+	b := NewBar(10)
+	b.Width = 6
+	b.Set(5)
+	b.rate = 1
+
+	b.SetError(errors.New("connection reset"))
+	if got, want := b.String(), "[\x1b[31m=>\x1b[0m--] "+ColorRed.Paint("✗ failed: connection reset"); !strings.HasSuffix(got, want) {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	if b.ETA() != 0 {
+		t.Fatal("want", "no ETA for a failed bar", "got", b.ETA())
+	}
+
+	b.Abort()
+	if got := b.ErrorString(); got != "✗ aborted" {
+		t.Fatal("want", "✗ aborted", "got", got)
+	}
+	b.SetError(nil)
+	if got := b.String(); got != "[=>--]" {
+		t.Fatal("want", "[=>--]", "got", got)
+	}
+}
@@ -0,0 +1,61 @@
+package uiprogress
+
+import (
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// ETAFormat is how the estimated time remaining is displayed
+type ETAFormat int
+
+const (
+	// ETACompact displays the time remaining as a duration, for example "2m13s"
+	ETACompact ETAFormat = iota
+
+	// ETAClock displays the time remaining as hours, minutes and seconds, for example "00:02:13"
+	ETAClock
+
+	// ETAHuman displays the time remaining in words, for example "about 2 minutes"
+	ETAHuman
+
+	// ETAFinish displays the time the bar is expected to finish at, for example "finishes at 14:32"
+	ETAFinish
+)
+
+// FinishLayout is the time layout of ETAFinish
+var FinishLayout = "15:04"
+
+// ETAFormatString returns the estimated time remaining in the format f
+func (b *Bar) ETAFormatString(f ETAFormat) string {
+	eta := b.ETA()
+	switch f {
+	case ETAClock:
+		return strutil.ClockTime(eta)
+	case ETAHuman:
+		return strutil.HumanTime(eta)
+	case ETAFinish:
+		if eta == 0 {
+			return "---"
+		}
+		return "finishes at " + b.now().Add(eta).Format(FinishLayout)
+	}
+	return strutil.PrettyTime(eta)
+}
+
+// now returns the current time of the clock of the bar
+func (b *Bar) now() time.Time {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.timeSource().Now()
+}
+
+// ETAWithFormat returns a decorator rendering the estimated time remaining in the format f
+func ETAWithFormat(f ETAFormat) Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		if f == ETACompact {
+			return strutil.PadLeft(b.ETAFormatString(f), 5, ' ')
+		}
+		return b.ETAFormatString(f)
+	})
+}
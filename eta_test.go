@@ -0,0 +1,26 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestETAFormatString(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2016, 1, 2, 14, 30, 0, 0, time.UTC)}
+	b := NewBar(100).SetClock(clk)
+	b.Incr()
+	clk.Sleep(time.Second * 2)
+	b.IncrBy(2)
+
+	cases := map[ETAFormat]string{
+		ETACompact: "1m37s",
+		ETAClock:   "00:01:37",
+		ETAHuman:   "about 2 minutes",
+		ETAFinish:  "finishes at 14:31",
+	}
+	for f, want := range cases {
+		if got := b.ETAFormatString(f); got != want {
+			t.Fatal("want", want, "got", got)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package uiprogress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Event is a progress update of a bar, published by the Emitter of a progress container
+type Event struct {
+	// Bar is the name of the bar
+	Bar string `json:"bar"`
+
+	// ID is the identifier of the bar in its container
+	ID int `json:"id"`
+
+	Current int64     `json:"current"`
+	Total   int64     `json:"total"`
+	Time    time.Time `json:"ts"`
+}
+
+// Emitter publishes progress events
+type Emitter interface {
+	Emit(e Event) error
+}
+
+// EmitterFunc is an adapter to use an ordinary function as an Emitter
+type EmitterFunc func(e Event) error
+
+// Emit calls f(e)
+func (f EmitterFunc) Emit(e Event) error {
+	return f(e)
+}
+
+// jsonEmitter writes the events as JSON lines
+type jsonEmitter struct {
+	enc *json.Encoder
+	mtx *sync.Mutex
+}
+
+// NewJSONEmitter returns an Emitter writing each event to w as a line of JSON, for example
+// {"bar":"download","id":0,"current":5,"total":10,"ts":"2016-01-02T15:04:05Z"}
+func NewJSONEmitter(w io.Writer) Emitter {
+	return &jsonEmitter{enc: json.NewEncoder(w), mtx: &sync.Mutex{}}
+}
+
+func (e *jsonEmitter) Emit(ev Event) error {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	return e.enc.Encode(ev)
+}
+
+// NewChanEmitter returns an Emitter sending the events on ch. Events are dropped when ch is not ready to receive,
+// so a slow consumer doesn't stall rendering.
+func NewChanEmitter(ch chan<- Event) Emitter {
+	return EmitterFunc(func(e Event) error {
+		select {
+		case ch <- e:
+		default:
+		}
+		return nil
+	})
+}
+
+// emit publishes an event for each bar that changed since the last call. The caller must hold the lock.
+func (p *Progress) emit() {
+	if p.Emitter == nil {
+		return
+	}
+	if p.emitted == nil {
+		p.emitted = make(map[*Bar]Event)
+	}
+	now := p.clock.Now()
+	for _, bar := range p.Bars {
+		bar.mtx.RLock()
+		e := Event{Bar: bar.name, ID: bar.id, Current: bar.current, Total: bar.Total64()}
+		bar.mtx.RUnlock()
+
+		last, ok := p.emitted[bar]
+		e.Time = last.Time
+		if ok && last == e {
+			continue
+		}
+		e.Time = now
+		p.emitted[bar] = e
+		p.Emitter.Emit(e)
+	}
+}
@@ -0,0 +1,57 @@
+package uiprogress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONEmitter(t *testing.T) {
+	var buf bytes.Buffer
+	p := New()
+	p.clock = &fakeClock{now: time.Unix(10, 0)}
+	p.RenderFunc = func([]string) {}
+	p.Emitter = NewJSONEmitter(&buf)
+	bar := p.AddBar(10).SetName("download")
+
+	bar.Set(5)
+	p.tick(nil)
+	p.tick(nil)
+	bar.Set(10)
+	p.tick(nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatal("want", 2, "events", "got", len(lines), buf.String())
+	}
+	var e Event
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Bar != "download" || e.Current != 5 || e.Total != 10 || e.Time.IsZero() {
+		t.Fatal("want", "download 5/10", "got", e)
+	}
+	if !strings.Contains(lines[1], `"current":10,`) {
+		t.Fatal("want", `"current":10`, "in", lines[1])
+	}
+}
+
+func TestChanEmitter(t *testing.T) {
+	ch := make(chan Event, 1)
+	p := New()
+	p.Emitter = NewChanEmitter(ch)
+	p.AddBar(10)
+	p.AddBar(10)
+	p.emit()
+
+	if e := <-ch; e.ID != 0 {
+		t.Fatal("want", 0, "got", e.ID)
+	}
+	select {
+	case e := <-ch:
+		t.Fatal("want", "event dropped when the channel is full", "got", e)
+	default:
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/gosuri/uiprogress/httpprogress"
+)
+
+const page = `<!DOCTYPE html>
+<pre id="bars"></pre>
+<script>
+new EventSource("/events").addEventListener("progress", function(e) {
+	document.getElementById("bars").textContent = JSON.parse(e.data).lines.join("\n");
+});
+</script>`
+
+func main() {
+	b := uiprogress.NewBroadcaster()
+	uiprogress.Default().Broadcast(b)
+
+	http.Handle("/events", httpprogress.SSEHandler(b))
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, page)
+	})
+	go func() {
+		log.Fatal(http.ListenAndServe("localhost:8080", nil))
+	}()
+
+	uiprogress.Start()
+	bar := uiprogress.AddBar(100).AppendCompleted().PrependElapsed()
+	for bar.Incr() {
+		time.Sleep(time.Millisecond * 200)
+	}
+	uiprogress.Stop()
+}
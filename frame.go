@@ -0,0 +1,147 @@
+package uiprogress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// frameWriter is the live writer the bars are drawn with. A frame of as many lines as the previous one is drawn by
+// moving the cursor to the lines that changed and rewriting only them, instead of clearing and writing the whole
+// frame, which saves most of the bytes written for many bars over remote connections. Other frames are drawn with
+// a LiveWriter.
+type frameWriter struct {
+	// live draws the frames in full, created with newLive when first needed
+	live    LiveWriter
+	newLive func() LiveWriter
+	out     io.Writer
+
+	// prev is the frame on the output, when it can be patched
+	prev []string
+}
+
+func newFrameWriter() *frameWriter {
+	return &frameWriter{newLive: NewLiveWriter, out: Out}
+}
+
+// writer returns the live writer, creating it when needed
+func (w *frameWriter) writer() LiveWriter {
+	if w.live == nil {
+		w.live = w.newLive()
+		w.live.SetOutput(w.out)
+	}
+	return w.live
+}
+
+// setOut sets the writer the frames are drawn on
+func (w *frameWriter) setOut(out io.Writer) {
+	w.out = out
+	if w.live != nil {
+		w.live.SetOutput(out)
+	}
+}
+
+// setLive makes the frames drawn with the live writers created by newLive from the next frame on
+func (w *frameWriter) setLive(newLive func() LiveWriter) {
+	w.newLive = newLive
+	w.live = nil
+	w.prev = nil
+}
+
+// Flush draws the lines written to the live writer
+func (w *frameWriter) Flush() error {
+	return w.writer().Flush()
+}
+
+// Bypass returns a writer for output above the frame. The frame is cleared before each write and the next one is
+// drawn in full.
+func (w *frameWriter) Bypass() io.Writer {
+	return frameBypass{w}
+}
+
+type frameBypass struct {
+	w *frameWriter
+}
+
+func (b frameBypass) Write(buf []byte) (int, error) {
+	b.w.prev = nil
+	return b.w.writer().Bypass().Write(buf)
+}
+
+// forget drops the frame on the output without erasing it, when it is no longer where the cursor left it. The next
+// frame is drawn in full below the cursor by a new live writer.
+func (w *frameWriter) forget() {
+	w.live = nil
+	w.prev = nil
+}
+
+// draw draws lines as the new frame and returns the number of bytes written. The frame is patched only when its
+// lines fit on a row of cols cells, lines wrapping over several rows are drawn in full.
+func (w *frameWriter) draw(lines []string, cols int) int {
+	if len(lines) == 0 {
+		// Flush skips an empty buffer, clear the previous frame through the bypass writer instead
+		w.Bypass().Write(nil)
+		return 0
+	}
+	if n, ok := w.patch(lines, cols); ok {
+		return n
+	}
+	n := 0
+	live := w.writer()
+	for _, line := range lines {
+		written, _ := fmt.Fprintln(live, line)
+		n += written
+	}
+	live.Flush()
+	w.prev = nil
+	if fitRows(lines, cols) {
+		w.prev = append([]string(nil), lines...)
+	}
+	return n
+}
+
+// patch rewrites the lines that changed since the previous frame, reporting false when the frame must be drawn in
+// full instead
+func (w *frameWriter) patch(lines []string, cols int) (int, bool) {
+	if len(w.prev) != len(lines) || !fitRows(lines, cols) {
+		return 0, false
+	}
+	var buf bytes.Buffer
+	// the cursor is at the start of the row below the frame
+	row := len(lines)
+	for i, line := range lines {
+		if line == w.prev[i] {
+			continue
+		}
+		if row > i {
+			fmt.Fprintf(&buf, "\x1b[%dA", row-i)
+		} else {
+			fmt.Fprintf(&buf, "\x1b[%dB", i-row)
+		}
+		fmt.Fprintf(&buf, "\r%s\x1b[K", line)
+		row = i
+	}
+	if buf.Len() == 0 {
+		return 0, true
+	}
+	fmt.Fprintf(&buf, "\x1b[%dB\r", len(lines)-row)
+	n, _ := w.out.Write(buf.Bytes())
+	copy(w.prev, lines)
+	return n, true
+}
+
+// fitRows reports whether each line fits on a single row of cols cells. A line filling the row is left out, as
+// erasing the rest of the row from its end would erase its last cell.
+func fitRows(lines []string, cols int) bool {
+	if cols <= 0 {
+		return false
+	}
+	for _, line := range lines {
+		if strutil.Width(line) >= cols {
+			return false
+		}
+	}
+	return true
+}
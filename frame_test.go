@@ -0,0 +1,48 @@
+package uiprogress
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFrameWriterPatch(t *testing.T) {
+	var out bytes.Buffer
+	w := newFrameWriter()
+	w.setOut(&out)
+	w.draw([]string{"a 1", "b 1", "c 1"}, 80)
+	out.Reset()
+
+	w.draw([]string{"a 1", "b 2", "c 1"}, 80)
+	if got, want := out.String(), "\x1b[2A\rb 2\x1b[K\x1b[2B\r"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	out.Reset()
+	w.draw([]string{"a 2", "b 2", "c 2"}, 80)
+	if got, want := out.String(), "\x1b[3A\ra 2\x1b[K\x1b[2B\rc 2\x1b[K\x1b[1B\r"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	out.Reset()
+	if n := w.draw([]string{"a 2", "b 2", "c 2"}, 80); n != 0 || out.Len() != 0 {
+		t.Fatalf("want nothing written for an unchanged frame, got %q", out.String())
+	}
+
+	// a frame of another height is drawn in full
+	w.draw([]string{"a 2", "b 2"}, 80)
+	if got, want := out.String(), clearLines(3)+"a 2\nb 2\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestFrameWriterWrap(t *testing.T) {
+	var out bytes.Buffer
+	w := newFrameWriter()
+	w.setOut(&out)
+	w.draw([]string{"a 1", "b 1"}, 4)
+	out.Reset()
+
+	// a line as wide as the terminal is drawn in full
+	w.draw([]string{"a 1", "b 10"}, 4)
+	if got, want := out.String(), clearLines(2)+"a 1\nb 10\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,51 @@
+package uiprogress
+
+// funcBarTotal is the total of bars polling a progress function, the resolution of their completion
+const funcBarTotal = 10000
+
+// AddFuncBar creates a bar polling progress for its completion and adds it to the default progress container
+func AddFuncBar(name string, progress func() float64, opts ...BarOption) *Bar {
+	return defaultProgress.AddFuncBar(name, progress, opts...)
+}
+
+// AddFuncBar creates a bar named name that calls progress on each frame for its completion, from 0.0 to 1.0, for
+// work that can't be counted with Set and Incr. The bar completes once progress returns 1.0.
+func (p *Progress) AddFuncBar(name string, progress func() float64, opts ...BarOption) *Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	bar := p.newBar(funcBarTotal, append([]BarOption{WithName(name)}, opts...))
+	bar.progressFunc = progress
+	p.addBar(bar)
+	return bar
+}
+
+// updateFunc sets a bar polling a progress function to its completion. It must be called without the lock held.
+func (b *Bar) updateFunc() {
+	b.mtx.RLock()
+	f, total, done := b.progressFunc, b.Total64(), b.completed()
+	b.mtx.RUnlock()
+	if f == nil || done {
+		return
+	}
+	v := f()
+	switch {
+	case v < 0:
+		v = 0
+	case v > 1:
+		v = 1
+	}
+	if n := int64(v * float64(total)); n != b.Current64() {
+		b.Set64(n)
+	}
+}
+
+// updateFuncs polls the progress functions of the bars of the container
+func (p *Progress) updateFuncs() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	p.mtx.RUnlock()
+	for _, bar := range bars {
+		bar.updateFunc()
+	}
+}
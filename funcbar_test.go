@@ -0,0 +1,29 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddFuncBar(t *testing.T) {
+	p := New()
+	p.clock = &fakeClock{now: time.Unix(0, 0)}
+	p.RenderFunc = func([]string) {}
+	done := 0.0
+	bar := p.AddFuncBar("index", func() float64 { return done })
+	if bar.Name() != "index" || !bar.animated() {
+		t.Fatal("want", "animated bar named index", "got", bar.Name(), bar.animated())
+	}
+
+	done = 0.42
+	p.tick(nil)
+	if got := bar.CompletedPercent(); got != 42 {
+		t.Fatal("want", 42, "got", got)
+	}
+
+	done = 1.5
+	p.tick(nil)
+	if !bar.Completed() || bar.animated() {
+		t.Fatal("want", "bar completed once the function reaches 1.0", "got", bar.CompletedPercent())
+	}
+}
@@ -0,0 +1,81 @@
+package uiprogress
+
+// Group is a labeled section of bars in a progress container, rendered as a header line followed by its bars
+type Group struct {
+	// Title is rendered on the header line of the group
+	Title string
+
+	// Collapse renders only the header once all the bars of the group are complete
+	Collapse bool
+
+	p *Progress
+}
+
+// AddGroup adds a group with the title to the container. Groups are rendered after the bars that aren't in a group,
+// in the order they were added.
+func (p *Progress) AddGroup(title string) *Group {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	g := &Group{Title: title, p: p}
+	p.groups = append(p.groups, g)
+	p.dirty = true
+	p.notifyChange()
+	return g
+}
+
+// AddBar creates a new progress bar configured with the options and adds it to the group
+func (g *Group) AddBar(total int, opts ...BarOption) *Bar {
+	p := g.p
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	bar := p.newBar(int64(total), opts)
+	bar.group = g
+	p.addBar(bar)
+	return bar
+}
+
+// Bars returns the bars of the group
+func (g *Group) Bars() []*Bar {
+	g.p.mtx.RLock()
+	defer g.p.mtx.RUnlock()
+	return g.bars(g.p.Bars)
+}
+
+// Completed reports whether all the bars of the group are complete
+func (g *Group) Completed() bool {
+	g.p.mtx.RLock()
+	defer g.p.mtx.RUnlock()
+	return g.completed(g.p.Bars)
+}
+
+// bars returns the bars of the group among bars, keeping their order
+func (g *Group) bars(bars []*Bar) []*Bar {
+	var grouped []*Bar
+	for _, bar := range bars {
+		if bar.group == g {
+			grouped = append(grouped, bar)
+		}
+	}
+	return grouped
+}
+
+func (g *Group) completed(bars []*Bar) bool {
+	for _, bar := range g.bars(bars) {
+		if !bar.Completed() {
+			return false
+		}
+	}
+	return true
+}
+
+// lines renders the header of the group and, unless it is collapsed, its bars among visible indented below it
+func (g *Group) lines(visible []*Bar, cols int) []string {
+	if g.Collapse && g.completed(g.p.Bars) {
+		return []string{g.Title + " " + SuccessMark}
+	}
+	lines := []string{g.Title}
+	for _, bar := range g.bars(visible) {
+		lines = append(lines, bar.treeLines(ChildIndent, cols-len(ChildIndent))...)
+	}
+	return lines
+}
@@ -0,0 +1,33 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddGroup(t *testing.T) {
+	p := New()
+	p.AddBar(10)
+	downloads := p.AddGroup("Downloads")
+	extraction := p.AddGroup("Extraction")
+	extraction.Collapse = true
+	first, second := downloads.AddBar(10), extraction.AddBar(10)
+	downloads.AddBar(10)
+
+	lines := p.Lines()
+	if len(lines) != 6 || lines[1] != "Downloads" || lines[4] != "Extraction" {
+		t.Fatal("want", "bar, Downloads and its 2 bars, Extraction and its bar", "got", lines)
+	}
+	if want := ChildIndent + first.String(); lines[2] != want {
+		t.Fatal("want", want, "got", lines[2])
+	}
+
+	second.Set(10)
+	lines = p.Lines()
+	if len(lines) != 5 || !strings.HasPrefix(lines[4], "Extraction "+SuccessMark) {
+		t.Fatal("want", "Extraction collapsed", "got", lines)
+	}
+	if len(downloads.Bars()) != 2 || downloads.Completed() {
+		t.Fatal("want", "2 incomplete bars in Downloads", "got", downloads.Bars())
+	}
+}
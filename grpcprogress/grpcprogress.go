@@ -0,0 +1,132 @@
+// Package grpcprogress attaches bars of a uiprogress container to the messages of gRPC client streams
+package grpcprogress
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/gosuri/uiprogress"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentLength is the metadata key announcing the number of bytes of the messages of a stream. Set it in the
+// outgoing metadata of a client stream for its upload bar, and in the header of a server stream for its download bar.
+const ContentLength = "content-length"
+
+// StreamClientInterceptor returns an interceptor adding a bar to p for the messages sent and received on each
+// streaming call, counting their encoded size. The total of a bar is taken from the ContentLength metadata, bars of
+// streams without it are indeterminate until the stream ends.
+func StreamClientInterceptor(p *uiprogress.Progress, opts ...uiprogress.BarOption) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			return nil, err
+		}
+		s := &stream{ClientStream: cs}
+		if desc.ClientStreams {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			s.up = newCounter(p, "upload "+method, contentLength(md), opts)
+		}
+		if desc.ServerStreams {
+			s.down = newCounter(p, "download "+method, 0, opts)
+		}
+		return s, nil
+	}
+}
+
+// contentLength returns the ContentLength of md, or 0 when md doesn't have a valid one
+func contentLength(md metadata.MD) int64 {
+	v := md.Get(ContentLength)
+	if len(v) == 0 {
+		return 0
+	}
+	n, err := strconv.ParseInt(v[0], 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// counter is a bar counting the bytes of the messages of one direction of a stream
+type counter struct {
+	bar *uiprogress.Bar
+	n   int64
+}
+
+func newCounter(p *uiprogress.Progress, name string, total int64, opts []uiprogress.BarOption) *counter {
+	bar := p.AddBar64(total, append([]uiprogress.BarOption{uiprogress.WithName(name)}, opts...)...)
+	bar.AppendBytes()
+	return &counter{bar: bar}
+}
+
+// add counts the encoded size of m, when it is a protocol buffer message
+func (c *counter) add(m interface{}) {
+	if msg, ok := m.(proto.Message); ok {
+		n := int64(proto.Size(msg))
+		c.n += n
+		c.bar.IncrBy64(n)
+	}
+}
+
+// done completes the bar with the bytes counted, or fails it with err
+func (c *counter) done(err error) {
+	if err != nil && err != io.EOF {
+		c.bar.SetError(err)
+		return
+	}
+	// the length was unknown or only an estimate, the bar completes with the bytes actually counted
+	c.bar.Finish64(c.n)
+}
+
+// stream is a grpc.ClientStream counting the messages sent and received on the bars of the call
+type stream struct {
+	grpc.ClientStream
+
+	up, down *counter
+	header   bool
+}
+
+func (s *stream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if s.up != nil {
+		if err != nil {
+			s.up.done(err)
+		} else {
+			s.up.add(m)
+		}
+	}
+	return err
+}
+
+func (s *stream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	if s.up != nil {
+		s.up.done(err)
+	}
+	return err
+}
+
+func (s *stream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if s.down == nil {
+		return err
+	}
+	if err != nil {
+		s.down.done(err)
+		return err
+	}
+	if !s.header {
+		// the header has been received with the first message, reading it doesn't block
+		s.header = true
+		if md, herr := s.ClientStream.Header(); herr == nil {
+			if n := contentLength(md); n > 0 {
+				s.down.bar.SetTotal64(n)
+			}
+		}
+	}
+	s.down.add(m)
+	return nil
+}
@@ -0,0 +1,110 @@
+package grpcprogress
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/gosuri/uiprogress"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// echo sends back every message of the stream, announcing the length of the response in its header
+func echo(_ interface{}, ss grpc.ServerStream) error {
+	md, _ := metadata.FromIncomingContext(ss.Context())
+	if err := ss.SendHeader(metadata.Pairs(ContentLength, md.Get(ContentLength)[0])); err != nil {
+		return err
+	}
+	for {
+		m := new(wrapperspb.StringValue)
+		if err := ss.RecvMsg(m); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := ss.SendMsg(m); err != nil {
+			return err
+		}
+	}
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	lis := bufconn.Listen(1 << 16)
+	srv := grpc.NewServer()
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*interface{})(nil),
+		Streams:     []grpc.StreamDesc{{StreamName: "Echo", Handler: echo, ServerStreams: true, ClientStreams: true}},
+	}, struct{}{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	p := uiprogress.New()
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(p)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	msgs := []*wrapperspb.StringValue{wrapperspb.String("hello"), wrapperspb.String("world")}
+	size := int64(proto.Size(msgs[0]) + proto.Size(msgs[1]))
+	ctx := metadata.AppendToOutgoingContext(context.Background(), ContentLength, "100")
+	cs, err := cc.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, "/test.Echo/Echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range msgs {
+		if err := cs.SendMsg(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if up := p.Bars[0]; up.Current64() != size || up.Total != 100 {
+		t.Fatal("want", size, "of", 100, "got", up.Current64(), "of", up.Total)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if err := cs.RecvMsg(new(wrapperspb.StringValue)); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, bar := range p.Bars {
+		if !bar.Completed() || bar.Current64() != size {
+			t.Fatal("want", "bar completed at", size, "got", bar.Name(), bar.Current64())
+		}
+	}
+
+	// the bars of an empty stream complete at 0 bytes
+	ctx = metadata.AppendToOutgoingContext(context.Background(), ContentLength, "0")
+	cs, err = cc.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, "/test.Echo/Echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.RecvMsg(new(wrapperspb.StringValue)); err != io.EOF {
+		t.Fatal("want", io.EOF, "got", err)
+	}
+	if len(p.Bars) != 4 {
+		t.Fatal("want", 4, "got", len(p.Bars))
+	}
+	for _, bar := range p.Bars[2:] {
+		if !bar.Completed() || bar.Current64() != 0 {
+			t.Fatal("want", "bar completed at 0", "got", bar.Name(), bar.Current64())
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package uiprogress
+
+import "time"
+
+// RenderStats describes a frame rendered by the render loop
+type RenderStats struct {
+	// Started is when the frame started rendering
+	Started time.Time
+
+	// Duration is the time taken to render the frame and write it to the output
+	Duration time.Duration
+
+	// Bytes is the number of bytes written for the frame when the bars are redrawn
+	Bytes int
+}
+
+// OnBeforeRender sets f to be called before each frame the render loop renders. It is called without the container
+// locked, so it can use the container.
+func (p *Progress) OnBeforeRender(f func()) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.beforeRender = f
+}
+
+// OnAfterRender sets f to be called with the stats of each frame the render loop rendered, for example to log slow
+// frames. It is called without the container locked, so it can use the container.
+func (p *Progress) OnAfterRender(f func(stats RenderStats)) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.afterRender = f
+}
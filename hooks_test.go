@@ -0,0 +1,37 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderHooks(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.clock = &fakeClock{now: time.Unix(0, 0)}
+	var calls []string
+	var stats RenderStats
+	p.OnBeforeRender(func() {
+		calls = append(calls, "before")
+		// the hooks may use the container
+		p.Lines()
+	})
+	p.OnAfterRender(func(s RenderStats) {
+		calls = append(calls, "after")
+		stats = s
+	})
+
+	p.tick(nil)
+	if len(calls) != 2 || calls[0] != "before" || calls[1] != "after" {
+		t.Fatal("want", "before and after", "got", calls)
+	}
+	if stats.Bytes == 0 || !stats.Started.Equal(time.Unix(0, 0).Add(p.RefreshInterval)) {
+		t.Fatal("want", "the stats of the frame", "got", stats)
+	}
+
+	p.Pause()
+	p.tick(nil)
+	if len(calls) != 2 {
+		t.Fatal("want", "no hooks while paused", "got", calls)
+	}
+}
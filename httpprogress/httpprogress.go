@@ -0,0 +1,95 @@
+// Package httpprogress attaches bars of a uiprogress container to the bodies of HTTP requests and responses, and
+// streams the progress of a container to browsers
+package httpprogress
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gosuri/uiprogress"
+)
+
+// Transport is an http.RoundTripper adding a bar to a progress container for the body of each request and response
+// it carries, counting the bytes sent and received. The total of a bar is the Content-Length of the body, bars of
+// bodies of unknown length are indeterminate until the body is read.
+type Transport struct {
+	// Base makes the requests, http.DefaultTransport when nil
+	Base http.RoundTripper
+
+	// Progress is the container the bars are added to
+	Progress *uiprogress.Progress
+
+	// Options are applied to every bar
+	Options []uiprogress.BarOption
+}
+
+// NewTransport returns a Transport adding bars to p for the bodies of the requests made by base
+func NewTransport(p *uiprogress.Progress, base http.RoundTripper, opts ...uiprogress.BarOption) *Transport {
+	return &Transport{Base: base, Progress: p, Options: opts}
+}
+
+// Wrap instruments c to add bars to p for the bodies of its requests and responses, and returns c
+func Wrap(c *http.Client, p *uiprogress.Progress, opts ...uiprogress.BarOption) *http.Client {
+	c.Transport = NewTransport(p, c.Transport, opts...)
+	return c
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	target := req.URL.Host + req.URL.Path
+	if req.Body != nil && req.Body != http.NoBody {
+		// the request must not be modified, send a copy reading the body through the bar
+		up := *req
+		up.Body = t.track(req.Body, req.ContentLength, "upload "+target)
+		req = &up
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Body != nil && resp.Body != http.NoBody && req.Method != http.MethodHead {
+		resp.Body = t.track(resp.Body, resp.ContentLength, "download "+target)
+	}
+	return resp, nil
+}
+
+// track returns a body reading rc through a new bar of total n named name
+func (t *Transport) track(rc io.ReadCloser, n int64, name string) io.ReadCloser {
+	if n < 0 {
+		n = 0
+	}
+	bar := t.Progress.AddBar64(n, append([]uiprogress.BarOption{uiprogress.WithName(name)}, t.Options...)...)
+	bar.AppendBytes()
+	return &body{rc: rc, r: bar.NewProxyReader(rc), bar: bar}
+}
+
+// body is a request or response body incrementing its bar by the number of bytes read
+type body struct {
+	rc  io.ReadCloser
+	r   io.Reader
+	bar *uiprogress.Bar
+
+	read int64
+}
+
+func (b *body) Read(buf []byte) (int, error) {
+	n, err := b.r.Read(buf)
+	b.read += int64(n)
+	switch {
+	case err == io.EOF:
+		// the length was unknown or only an estimate, the bar completes with the bytes actually read
+		b.bar.Finish64(b.read)
+	case err != nil && err != io.EOF:
+		b.bar.SetError(err)
+	}
+	return n, err
+}
+
+func (b *body) Close() error {
+	return b.rc.Close()
+}
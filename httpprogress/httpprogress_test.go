@@ -0,0 +1,70 @@
+package httpprogress
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gosuri/uiprogress"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+
+	p := uiprogress.New()
+	c := Wrap(&http.Client{}, p)
+	resp, err := c.Post(srv.URL+"/echo", "text/plain", strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || string(b) != "hello world" {
+		t.Fatal("want", "hello world", "got", string(b), err)
+	}
+
+	if len(p.Bars) != 2 {
+		t.Fatal("want", 2, "got", len(p.Bars))
+	}
+	for _, bar := range p.Bars {
+		if !bar.Completed() || bar.Current() != 11 {
+			t.Fatal("want", "bar completed at 11 bytes", "got", bar.Name(), bar.Current())
+		}
+	}
+	if name := p.Bars[1].Name(); !strings.HasPrefix(name, "download ") || !strings.HasSuffix(name, "/echo") {
+		t.Fatal("want", "download bar of /echo", "got", name)
+	}
+}
+
+func TestTransportEmptyBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(ioutil.Discard, r.Body)
+		// flushing without writing sends an empty body of unknown length
+		w.(http.Flusher).Flush()
+	}))
+	defer srv.Close()
+
+	p := uiprogress.New()
+	c := Wrap(&http.Client{}, p)
+	resp, err := c.Post(srv.URL+"/empty", "text/plain", struct{ io.Reader }{strings.NewReader("")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if len(p.Bars) != 2 {
+		t.Fatal("want", 2, "got", len(p.Bars))
+	}
+	for _, bar := range p.Bars {
+		if !bar.Completed() || bar.Current() != 0 {
+			t.Fatal("want", "bar completed at 0 bytes", "got", bar.Name(), bar.Current())
+		}
+	}
+}
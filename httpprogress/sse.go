@@ -0,0 +1,78 @@
+package httpprogress
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+)
+
+// sseUpdate is the JSON data of a server-sent event
+type sseUpdate struct {
+	Time  time.Time `json:"ts"`
+	Lines []string  `json:"lines"`
+	Bars  []sseBar  `json:"bars"`
+}
+
+// sseBar is the state of a bar in a server-sent event
+type sseBar struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Current   int64   `json:"current"`
+	Total     int64   `json:"total"`
+	Rate      float64 `json:"rate"`
+	Completed bool    `json:"completed"`
+	Err       string  `json:"error,omitempty"`
+}
+
+// SSEHandler returns a handler streaming the updates of b as server-sent events, for a browser to mirror the
+// progress with an EventSource. Each update is a "progress" event with its lines and bars as JSON data, for example
+// {"ts":"2016-01-02T15:04:05Z","lines":["[==>---]"],"bars":[{"id":0,"name":"download","current":5,"total":10,...}]}
+func SSEHandler(b *uiprogress.Broadcaster) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		updates, cancel := b.Subscribe()
+		defer cancel()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(newSSEUpdate(u))
+				if err != nil {
+					return
+				}
+				if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// newSSEUpdate returns the data of the event of u
+func newSSEUpdate(u uiprogress.Update) sseUpdate {
+	e := sseUpdate{Time: u.Time, Lines: u.Lines, Bars: make([]sseBar, len(u.Bars))}
+	for i, s := range u.Bars {
+		e.Bars[i] = sseBar{ID: s.ID, Name: s.Name, Current: s.Current, Total: s.Total, Rate: s.Rate, Completed: s.Completed}
+		if s.Err != nil {
+			e.Bars[i].Err = s.Err.Error()
+		}
+	}
+	return e
+}
@@ -0,0 +1,48 @@
+package httpprogress
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+)
+
+func TestSSEHandler(t *testing.T) {
+	b := uiprogress.NewBroadcaster()
+	b.Publish(uiprogress.Update{
+		Time:  time.Unix(0, 0).UTC(),
+		Lines: []string{"[==>--]"},
+		Bars:  []uiprogress.BarState{{Name: "download", Current: 5, Total: 10, Err: errors.New("boom")}},
+	})
+	srv := httptest.NewServer(SSEHandler(b))
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatal("want", "text/event-stream", "got", got)
+	}
+
+	r := bufio.NewReader(resp.Body)
+	event, _ := r.ReadString('\n')
+	data, _ := r.ReadString('\n')
+	if event != "event: progress\n" || !strings.HasPrefix(data, "data: ") {
+		t.Fatalf("want a progress event, got %q %q", event, data)
+	}
+	var u sseUpdate
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &u); err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Lines) != 1 || u.Lines[0] != "[==>--]" || u.Bars[0].Name != "download" || u.Bars[0].Err != "boom" {
+		t.Fatal("want", "the published update", "got", u)
+	}
+	b.Close()
+}
@@ -0,0 +1,79 @@
+package uiprogress
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+)
+
+// KeyCtrlC is the key read when Ctrl+C is pressed with input enabled
+const KeyCtrlC = rune(3)
+
+// CancelKeys are the keys stopping a progress with input enabled
+var CancelKeys = []rune{'q', KeyCtrlC}
+
+// ErrNoInput is returned by EnableInput when there is no terminal to read keys from
+var ErrNoInput = errors.New("errors: no terminal to read input from")
+
+// EnableInput switches the controlling terminal to raw mode until Stop is called and calls handler with each key
+// pressed. On one of CancelKeys the display is torn down, the progress stopped and the terminal restored before
+// handler is called, so it can cancel the work and exit. Call it after Start.
+func (p *Progress) EnableInput(handler func(key rune)) error {
+	p.mtx.RLock()
+	stopChan := p.stopChan
+	p.mtx.RUnlock()
+	if stopChan == nil {
+		return nil
+	}
+
+	in, restore, err := openInput()
+	if err != nil {
+		return err
+	}
+	var once sync.Once
+	closeInput := func() {
+		once.Do(func() {
+			restore()
+			in.Close()
+		})
+	}
+	go func() {
+		<-stopChan
+		closeInput()
+	}()
+	go p.readInput(in, handler, closeInput)
+	return nil
+}
+
+// readInput calls handler with each key read from in, stopping the progress and calling closeInput on a cancel key
+func (p *Progress) readInput(in io.Reader, handler func(key rune), closeInput func()) {
+	r := bufio.NewReader(in)
+	for {
+		key, _, err := r.ReadRune()
+		if err != nil {
+			return
+		}
+		if isCancelKey(key) {
+			p.restoreTerminal()
+			p.Stop()
+			closeInput()
+		}
+		if handler != nil {
+			handler(key)
+		}
+		if isCancelKey(key) {
+			return
+		}
+	}
+}
+
+// isCancelKey reports whether key is one of CancelKeys
+func isCancelKey(key rune) bool {
+	for _, k := range CancelKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package uiprogress
+
+import "syscall"
+
+// ioctl requests getting and setting the terminal attributes
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,11 @@
+//go:build !windows && !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+// +build !windows,!linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly
+
+package uiprogress
+
+import "io"
+
+// openInput reports that raw terminal input isn't supported on this platform
+func openInput() (io.ReadCloser, func(), error) {
+	return nil, nil, ErrNoInput
+}
@@ -0,0 +1,30 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadInput(t *testing.T) {
+	var out bytes.Buffer
+	p := New().WithOutput(&out)
+	if _, err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	var keys []rune
+	closed := false
+	p.readInput(strings.NewReader("xqy"), func(key rune) {
+		p.mtx.RLock()
+		running := p.running
+		p.mtx.RUnlock()
+		if key == 'q' && (running || !closed) {
+			t.Fatal("want", "the display torn down before the cancel key is handled")
+		}
+		keys = append(keys, key)
+	}, func() { closed = true })
+
+	if string(keys) != "xq" {
+		t.Fatal("want", "xq", "got", string(keys))
+	}
+}
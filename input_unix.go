@@ -0,0 +1,53 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+// +build linux darwin freebsd netbsd openbsd dragonfly
+
+package uiprogress
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// openInput opens the controlling terminal in raw mode, returning a function restoring its mode
+func openInput() (io.ReadCloser, func(), error) {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return nil, nil, ErrNoInput
+	}
+	// the descriptor is used through RawControl, as Fd would make reads block past Close
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		tty.Close()
+		return nil, nil, ErrNoInput
+	}
+	var old syscall.Termios
+	if err := termios(conn, ioctlGetTermios, &old); err != nil {
+		tty.Close()
+		return nil, nil, ErrNoInput
+	}
+	raw := old
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
+	raw.Cc[syscall.VMIN], raw.Cc[syscall.VTIME] = 1, 0
+	if err := termios(conn, ioctlSetTermios, &raw); err != nil {
+		tty.Close()
+		return nil, nil, err
+	}
+	return tty, func() { termios(conn, ioctlSetTermios, &old) }, nil
+}
+
+// termios gets or sets the terminal attributes of conn with the ioctl request req
+func termios(conn syscall.RawConn, req uintptr, t *syscall.Termios) error {
+	var errno syscall.Errno
+	err := conn.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	})
+	if err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
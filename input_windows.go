@@ -0,0 +1,33 @@
+package uiprogress
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// console input mode flags turned off for raw input
+const (
+	enableProcessedInput = 0x0001
+	enableLineInput      = 0x0002
+	enableEchoInput      = 0x0004
+)
+
+// openInput opens the console input in raw mode, returning a function restoring its mode
+func openInput() (io.ReadCloser, func(), error) {
+	in, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, ErrNoInput
+	}
+	h := syscall.Handle(in.Fd())
+	mode, err := getConsoleMode(h)
+	if err != nil {
+		in.Close()
+		return nil, nil, ErrNoInput
+	}
+	if err := setConsoleMode(h, mode&^(enableProcessedInput|enableLineInput|enableEchoInput)); err != nil {
+		in.Close()
+		return nil, nil, err
+	}
+	return in, func() { setConsoleMode(h, mode) }, nil
+}
@@ -0,0 +1,54 @@
+package uiprogress
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// showCursor is the escape sequence making the terminal cursor visible
+const showCursor = "\x1b[?25h"
+
+// InterruptSignals are the signals HandleInterrupt restores the terminal on
+var InterruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// raise delivers sig again with its default behavior, replaceable in tests
+var raise = raiseSignal
+
+// HandleInterrupt makes the progress, until Stop is called, draw a final frame and restore the terminal when the
+// program receives one of InterruptSignals. The signal is then delivered again, so the program still terminates.
+func (p *Progress) HandleInterrupt() {
+	p.mtx.RLock()
+	stopChan := p.stopChan
+	p.mtx.RUnlock()
+	if stopChan == nil {
+		return
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, InterruptSignals...)
+	go func() {
+		defer signal.Stop(sigs)
+		select {
+		case <-stopChan:
+		case sig := <-sigs:
+			p.restoreTerminal()
+			p.Stop()
+			signal.Stop(sigs)
+			raise(sig)
+		}
+	}()
+}
+
+// restoreTerminal draws a final frame and shows the cursor
+func (p *Progress) restoreTerminal() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if !p.paused {
+		p.render()
+	}
+	if !p.plain && p.renderer() == nil {
+		fmt.Fprint(p.lw.out, showCursor)
+	}
+}
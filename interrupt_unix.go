@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// raiseSignal resets the handler of sig and sends it to the process again
+func raiseSignal(sig os.Signal) {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		os.Exit(1)
+	}
+	signal.Reset(s)
+	syscall.Kill(os.Getpid(), s)
+}
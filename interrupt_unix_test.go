@@ -0,0 +1,42 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleInterrupt(t *testing.T) {
+	raised := make(chan os.Signal, 1)
+	raise = func(sig os.Signal) { raised <- sig }
+	defer func() { raise = raiseSignal }()
+
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	p.AddBar(10)
+	p.HandleInterrupt()
+
+	syscall.Kill(os.Getpid(), syscall.SIGTERM)
+	select {
+	case sig := <-raised:
+		if sig != syscall.SIGTERM {
+			t.Fatal("want", syscall.SIGTERM, "got", sig)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want", "signal raised again")
+	}
+	if got := out.String(); !strings.Contains(got, "[") || !strings.HasSuffix(got, showCursor) {
+		t.Fatalf("want final frame and cursor shown, got %q", got)
+	}
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.stopChan != nil {
+		t.Fatal("want", "progress stopped")
+	}
+}
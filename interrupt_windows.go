@@ -0,0 +1,8 @@
+package uiprogress
+
+import "os"
+
+// raiseSignal exits the process, signals can't be sent to the process again on Windows
+func raiseSignal(sig os.Signal) {
+	os.Exit(1)
+}
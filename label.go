@@ -0,0 +1,100 @@
+package uiprogress
+
+import "github.com/gosuri/uiprogress/util/strutil"
+
+// MinAutoWidth is the smallest track of a WidthAuto bar. Prepended labels are cut with an ellipsis to make room
+// for it when the line is too narrow.
+var MinAutoWidth = 10
+
+// SetEllipsis sets where the labels of the bar are cut when they don't fit, strutil.EllipsisEnd by default
+func (b *Bar) SetEllipsis(pos strutil.EllipsisPosition) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.ellipsis = pos
+	b.markDirty()
+	return b
+}
+
+// ellipsisPosition returns where the labels of the bar are cut
+func (b *Bar) ellipsisPosition() strutil.EllipsisPosition {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.ellipsis
+}
+
+// AppendFuncWidth renders the output of the decorator function on the right of the progress bar in exactly width
+// cells, cut with an ellipsis or padded. A WidthAuto track takes the width the decorators leave.
+func (b *Bar) AppendFuncWidth(f DecoratorFunc, width int) *Bar {
+	return b.AppendFunc(fixedWidth(f, width))
+}
+
+// PrependFuncWidth renders the output of the decorator function on the left of the progress bar in exactly width
+// cells, cut with an ellipsis or padded. A WidthAuto track takes the width the decorators leave.
+func (b *Bar) PrependFuncWidth(f DecoratorFunc, width int) *Bar {
+	return b.PrependFunc(fixedWidth(f, width))
+}
+
+// fixedWidth returns a decorator function fitting the output of f in width cells
+func fixedWidth(f DecoratorFunc, width int) DecoratorFunc {
+	return func(b *Bar) string {
+		return strutil.Fit(f(b), width, b.ellipsisPosition())
+	}
+}
+
+// fitPrepends cuts the prepended labels of a WidthAuto bar, the first added first, until the track has at least
+// MinAutoWidth cells
+func (b *Bar) fitPrepends(prepends, appends []string) []string {
+	b.mtx.RLock()
+	known, pos := b.lineWidth > 0, b.ellipsis
+	b.mtx.RUnlock()
+	if !known {
+		return prepends
+	}
+	over := MinAutoWidth - b.autoWidth(prepends, appends)
+	for i := 0; i < len(prepends) && over > 0; i++ {
+		width := strutil.Width(prepends[i])
+		cut := width - 1
+		if cut > over {
+			cut = over
+		}
+		if cut > 0 {
+			prepends[i] = strutil.Ellipsize(prepends[i], width-cut, pos)
+			over -= cut
+		}
+	}
+	return prepends
+}
+
+// SetLabel sets the label of the bar without taking its lock, for text updated while the bar runs, like the file
+// being processed. The label is rendered by the decorators added with AppendLabel and PrependLabel, in a column of
+// fixed width so the bar doesn't shift as the label changes.
+func (b *Bar) SetLabel(label string) {
+	b.label.Store(label)
+	select {
+	case b.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Label returns the label set with SetLabel
+func (b *Bar) Label() string {
+	label, _ := b.label.Load().(string)
+	return label
+}
+
+// LabelColumn returns a decorator rendering the label of the bar in exactly width cells, cut with an ellipsis or padded
+func LabelColumn(width int) Decorator {
+	return fixedWidth(func(b *Bar) string {
+		return b.Label()
+	}, width)
+}
+
+// AppendLabel renders the label of the bar on the right of the progress bar in exactly width cells
+func (b *Bar) AppendLabel(width int) *Bar {
+	return b.AppendDecorator(LabelColumn(width))
+}
+
+// PrependLabel renders the label of the bar on the left of the progress bar in exactly width cells
+func (b *Bar) PrependLabel(width int) *Bar {
+	return b.PrependDecorator(LabelColumn(width))
+}
@@ -0,0 +1,54 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+func TestFitPrepends(t *testing.T) {
+	path := "/var/lib/docker/overlay2/layers/archive.tar"
+	b := NewBar(10).PrependFunc(func(*Bar) string { return path }).AppendCompleted()
+	b.Width = WidthAuto
+	b.SetEllipsis(strutil.EllipsisStart)
+	b.setLineWidth(30)
+
+	got := b.String()
+	if strutil.Width(got) != 30 {
+		t.Fatal("want", 30, "got", strutil.Width(got))
+	}
+	if !strings.HasPrefix(got, "…") || !strings.Contains(got, "archive.tar ") {
+		t.Fatal("want", "the end of the path", "got", got)
+	}
+	if track := strings.Count(got, "-") + 2; track != MinAutoWidth {
+		t.Fatal("want", MinAutoWidth, "got", track)
+	}
+}
+
+func TestPrependFuncWidth(t *testing.T) {
+	label := "a"
+	b := NewBar(10).PrependFuncWidth(func(*Bar) string { return label }, 4)
+	b.Width = 4
+	if got := b.String(); got != "a    [--]" {
+		t.Fatal("want", "a    [--]", "got", got)
+	}
+	label = "abcdef"
+	b.Invalidate()
+	if got := b.String(); got != "abc… [--]" {
+		t.Fatal("want", "abc… [--]", "got", got)
+	}
+}
+
+func TestSetLabel(t *testing.T) {
+	bar := NewBar(10).PrependLabel(8)
+	bar.Width = 6
+	bar.SetLabel("a.txt")
+	if got := bar.String(); got != "a.txt    [----]" {
+		t.Fatalf("want %q, got %q", "a.txt    [----]", got)
+	}
+	bar.SetLabel("archive.tar.gz")
+	if got := bar.String(); got != "archive… [----]" {
+		t.Fatalf("want %q, got %q", "archive… [----]", got)
+	}
+}
@@ -0,0 +1,127 @@
+package uiprogress
+
+import "github.com/gosuri/uiprogress/util/strutil"
+
+// Align is how the text of a column is aligned within its width
+type Align int
+
+const (
+	// AlignLeft pads the text of a column on the right
+	AlignLeft Align = iota
+
+	// AlignRight pads the text of a column on the left
+	AlignRight
+)
+
+// Column is a column of the grid laid out by SetColumns
+type Column struct {
+	// Decorator renders the text of the column. A nil Decorator renders the progress indicator.
+	Decorator Decorator
+
+	// Width is the number of cells of the column, the text being cut with an ellipsis or padded to it. A Width
+	// of 0 keeps the text as is. The progress indicator takes the width of the bar, or the width the other
+	// columns leave on the line for WidthAuto bars.
+	Width int
+
+	// Align is how the text is padded within Width
+	Align Align
+}
+
+// BarColumn returns the column of the progress indicator
+func BarColumn() Column {
+	return Column{}
+}
+
+// TextColumn returns a column rendering d in width cells aligned by align
+func TextColumn(d Decorator, width int, align Align) Column {
+	return Column{Decorator: d, Width: width, Align: align}
+}
+
+// BarName returns a decorator rendering the name of the bar
+func BarName() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.Name()
+	})
+}
+
+// SetColumns lays the bar out in columns separated by a space instead of its decorators, for bars to align on
+// the same grid. No columns restore the decorators.
+func (b *Bar) SetColumns(cols ...Column) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.columns = cols
+	b.markDirty()
+	return b
+}
+
+// SetColumns lays all the bars of the container out in the columns, including the ones added later, so they align
+// even when their texts differ in length. For example
+//
+//	p.SetColumns(TextColumn(BarName(), 20, AlignLeft), BarColumn(), TextColumn(Percentage(), 6, AlignRight))
+func (p *Progress) SetColumns(cols ...Column) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.columns = cols
+	for _, bar := range p.Bars {
+		bar.SetColumns(cols...)
+	}
+}
+
+// layout returns the columns of the bar, or nil
+func (b *Bar) layout() []Column {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.columns
+}
+
+// columnsParts lays the bar out in the columns
+func (b *Bar) columnsParts(cols []Column, width int) []linePart {
+	texts := make([]string, len(cols))
+	used := len(cols) - 1
+	for i, col := range cols {
+		if col.Decorator == nil {
+			continue
+		}
+		texts[i] = fitColumn(col.Decorator.Decor(b), col, b.ellipsisPosition())
+		used += strutil.Width(texts[i])
+	}
+
+	if width == WidthAuto {
+		b.mtx.RLock()
+		cols := b.lineWidth
+		b.mtx.RUnlock()
+		width = Width
+		if cols > 0 {
+			width = cols - used
+		}
+	}
+
+	var parts []linePart
+	var text []byte
+	for i, col := range cols {
+		if i > 0 {
+			text = append(text, ' ')
+		}
+		if col.Decorator == nil {
+			if track := b.track(width); len(track) > 0 {
+				parts = append(parts, linePart{text: string(text)}, linePart{track: track})
+				text = nil
+			}
+			continue
+		}
+		text = append(text, texts[i]...)
+	}
+	return append(parts, linePart{text: string(text)})
+}
+
+// fitColumn cuts or pads s to the width of col
+func fitColumn(s string, col Column, pos strutil.EllipsisPosition) string {
+	if col.Width <= 0 {
+		return s
+	}
+	s = strutil.Ellipsize(s, col.Width, pos)
+	if col.Align == AlignRight {
+		return strutil.PadLeft(s, col.Width, ' ')
+	}
+	return strutil.PadRight(s, col.Width, ' ')
+}
@@ -0,0 +1,34 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+func TestSetColumns(t *testing.T) {
+	p := New()
+	p.Width = 8
+	p.SetColumns(TextColumn(BarName(), 6, AlignLeft), BarColumn(), TextColumn(Percentage(), 5, AlignRight))
+	p.AddNamedBar("a", 10).Set(5)
+	p.AddNamedBar("archive.tar", 10)
+
+	lines := p.Lines()
+	want := []string{"a      [==>---]   50%", "archi… [------]    0%"}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatal("want", want[i], "got", lines[i])
+		}
+	}
+}
+
+func TestSetColumnsWidthAuto(t *testing.T) {
+	b := NewBar(10).SetName("file").SetColumns(TextColumn(BarName(), 10, AlignLeft), BarColumn())
+	b.Width = WidthAuto
+	b.setLineWidth(30)
+	got := b.String()
+	if strutil.Width(got) != 30 || !strings.HasPrefix(got, "file       [") {
+		t.Fatal("want", "the track filling the line after the name", "got", got)
+	}
+}
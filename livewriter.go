@@ -0,0 +1,135 @@
+package uiprogress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gosuri/uilive"
+)
+
+// LiveWriter draws the frames of the bars in place on a terminal. The lines of a frame are written to it and Flush
+// draws them over the previous frame. Frames of as many lines as the previous one, fitting the terminal width, are
+// patched by the progress container with cursor movements to the lines that changed instead.
+type LiveWriter interface {
+	io.Writer
+
+	// Flush draws the lines written since the last flush in place of the previous frame
+	Flush() error
+
+	// Bypass returns a writer for output above the frame, the frame being cleared before each write
+	Bypass() io.Writer
+
+	// SetOutput sets the writer the frames are drawn on
+	SetOutput(out io.Writer)
+}
+
+// NewLiveWriter creates the live writers of new progress containers, NewANSIWriter by default. Its frames are cleared
+// by their number of lines, which the progress container fits to the terminal width in cells.
+var NewLiveWriter = NewANSIWriter
+
+// SetLiveWriter makes the container draw its frames with the live writers created by newWriter, which is called
+// again for a new writer when the frame on the terminal is given up, as when the process resumes after being
+// suspended. It is to be called before Start, containers sharing a terminal draw with the writer of the first one.
+func (p *Progress) SetLiveWriter(newWriter func() LiveWriter) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.lw.setLive(newWriter)
+}
+
+// uiliveWriter is a LiveWriter drawing with a uilive.Writer
+type uiliveWriter struct {
+	*uilive.Writer
+}
+
+// uiliveMtx serializes the creation of uilive writers, which detect the terminal size into package variables
+var uiliveMtx = &sync.Mutex{}
+
+// NewUILiveWriter returns a LiveWriter drawing with github.com/gosuri/uilive, which erases the previous frame line
+// by line, accounting for lines wrapping over several rows. uilive measures lines in bytes, so a line of colors or
+// multibyte runes filling the terminal width is counted as several rows and frames drawn with it erase the output
+// above them.
+func NewUILiveWriter() LiveWriter {
+	uiliveMtx.Lock()
+	defer uiliveMtx.Unlock()
+	return uiliveWriter{uilive.New()}
+}
+
+func (w uiliveWriter) SetOutput(out io.Writer) {
+	w.Out = out
+}
+
+// ansiWriter is a LiveWriter drawing with ANSI escape sequences
+type ansiWriter struct {
+	out io.Writer
+	buf bytes.Buffer
+	// lines is the number of lines of the frame on the output
+	lines int
+	mtx   *sync.Mutex
+}
+
+// NewANSIWriter returns a LiveWriter moving the cursor up to the start of the previous frame and erasing the rest
+// of the screen with a single escape sequence before drawing the next, without the system calls of uilive. Lines
+// wrapping over several rows are counted as one, so they must fit the terminal width.
+func NewANSIWriter() LiveWriter {
+	return &ansiWriter{out: Out, mtx: &sync.Mutex{}}
+}
+
+func (w *ansiWriter) Write(buf []byte) (int, error) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.buf.Write(buf)
+}
+
+func (w *ansiWriter) Flush() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var frame bytes.Buffer
+	w.clear(&frame)
+	frame.Write(w.buf.Bytes())
+	w.lines = bytes.Count(w.buf.Bytes(), []byte("\n"))
+	w.buf.Reset()
+	_, err := w.out.Write(frame.Bytes())
+	return err
+}
+
+func (w *ansiWriter) Bypass() io.Writer {
+	return ansiBypass{w}
+}
+
+func (w *ansiWriter) SetOutput(out io.Writer) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	w.out = out
+}
+
+// clear writes the escape sequences erasing the frame on the output to buf. The caller must hold the lock.
+func (w *ansiWriter) clear(buf *bytes.Buffer) {
+	if w.lines > 0 {
+		fmt.Fprintf(buf, "\x1b[%dA\r\x1b[J", w.lines)
+	}
+}
+
+type ansiBypass struct {
+	w *ansiWriter
+}
+
+func (b ansiBypass) Write(buf []byte) (int, error) {
+	b.w.mtx.Lock()
+	defer b.w.mtx.Unlock()
+	var out bytes.Buffer
+	b.w.clear(&out)
+	out.Write(buf)
+	b.w.lines = 0
+	if out.Len() == 0 {
+		return 0, nil
+	}
+	if _, err := b.w.out.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(buf), nil
+}
@@ -0,0 +1,61 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestANSIWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewANSIWriter()
+	w.SetOutput(&out)
+
+	w.Write([]byte("a\nb\n"))
+	w.Flush()
+	w.Write([]byte("c\n"))
+	w.Flush()
+	if got, want := out.String(), "a\nb\n\x1b[2A\r\x1b[Jc\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	out.Reset()
+	w.Bypass().Write([]byte("log\n"))
+	w.Write([]byte("d\n"))
+	w.Flush()
+	if got, want := out.String(), "\x1b[1A\r\x1b[Jlog\nd\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestLiveWriterCountsLines(t *testing.T) {
+	var out bytes.Buffer
+	w := NewLiveWriter()
+	w.SetOutput(&out)
+
+	// a line of multibyte runes filling the terminal takes a single row
+	w.Write([]byte(strings.Repeat("█", 80) + "\n"))
+	w.Flush()
+	out.Reset()
+	w.Write([]byte("x\n"))
+	w.Flush()
+	if got, want := out.String(), "\x1b[1A\r\x1b[Jx\n"; got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestSetLiveWriter(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.SetLiveWriter(NewUILiveWriter)
+	p.lw.setOut(out)
+	for i := 0; i < 2; i++ {
+		p.Bars[0].Incr()
+		p.mtx.Lock()
+		p.render()
+		p.mtx.Unlock()
+	}
+	if got := out.String(); !strings.Contains(got, "\x1b[1A\x1b[2K[") {
+		t.Fatalf("want frame drawn with uilive, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package uiprogress
+
+// marker is a glyph drawn on the track at a fraction of its width
+type marker struct {
+	fraction float64
+	glyph    rune
+}
+
+// AddMarker draws glyph on the track at fraction of its width, from 0.0 to 1.0, in the color of the fill or empty
+// cell it covers, for example at the chunk boundaries of a segmented download. Indeterminate bars have no markers.
+func (b *Bar) AddMarker(fraction float64, glyph rune) *Bar {
+	switch {
+	case fraction < 0:
+		fraction = 0
+	case fraction > 1:
+		fraction = 1
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.markers = append(b.markers, marker{fraction: fraction, glyph: glyph})
+	b.markDirty()
+	return b
+}
+
+// ClearMarkers removes the markers added with AddMarker
+func (b *Bar) ClearMarkers() *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.markers = nil
+	b.markDirty()
+	return b
+}
+
+// markGlyphs returns the glyph of the marker drawn on each cell of track, 0 for cells without one, or nil when the
+// bar has no markers. Markers are placed between the ends of the track, the last added winning a shared cell.
+func (b *Bar) markGlyphs(track []Style) []rune {
+	b.mtx.RLock()
+	markers, total := b.markers, b.Total64()
+	b.mtx.RUnlock()
+	if len(markers) == 0 || total <= 0 {
+		return nil
+	}
+
+	start, end := trackBounds(track)
+	if start >= end {
+		return nil
+	}
+	marks := make([]rune, len(track))
+	for _, m := range markers {
+		i := start + int(m.fraction*float64(end-start))
+		if i >= end {
+			i = end - 1
+		}
+		marks[i] = m.glyph
+	}
+	return marks
+}
+
+// trackBounds returns the range of the cells of track between its ends
+func trackBounds(track []Style) (start, end int) {
+	start, end = 0, len(track)
+	if start < end && track[start] == StyleLeftEnd {
+		start++
+	}
+	if start < end && track[end-1] == StyleRightEnd {
+		end--
+	}
+	return start, end
+}
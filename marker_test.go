@@ -0,0 +1,21 @@
+package uiprogress
+
+import "testing"
+
+func TestAddMarker(t *testing.T) {
+	b := NewBar(100)
+	b.Width = 12
+	b.Set(50)
+	b.AddMarker(0.25, '|').AddMarker(0.8, '|')
+	if got, want := b.String(), "[==|=>---|-]"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	b.AddMarker(2, '*')
+	if got, want := b.String(), "[==|=>---|*]"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	b.ClearMarkers()
+	if got, want := b.String(), "[====>-----]"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
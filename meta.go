@@ -0,0 +1,63 @@
+package uiprogress
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+const (
+	// MetaWorker is the metadata key for the id of the worker the bar belongs to
+	MetaWorker = "worker"
+
+	// MetaItem is the metadata key for the item the bar is currently processing
+	MetaItem = "item"
+)
+
+// SetMeta sets the metadata value for the key, for decorators to render. It replaces any existing value.
+func (b *Bar) SetMeta(key string, value interface{}) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.meta == nil {
+		b.meta = make(map[string]interface{})
+	}
+	b.meta[key] = value
+	b.markDirty()
+	return b
+}
+
+// Meta returns the metadata value for the key, or nil when it is not set
+func (b *Bar) Meta(key string) interface{} {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.meta[key]
+}
+
+// WorkerString returns the worker id and current item from the bar's metadata, for example "W3 file.txt"
+func (b *Bar) WorkerString() string {
+	var parts []string
+	if w := b.Meta(MetaWorker); w != nil {
+		parts = append(parts, fmt.Sprintf("W%v", w))
+	}
+	if item := b.Meta(MetaItem); item != nil {
+		parts = append(parts, fmt.Sprint(item))
+	}
+	return strings.Join(parts, " ")
+}
+
+// AppendWorker appends the worker id and current item to the bar, resized to width
+func (b *Bar) AppendWorker(width int) *Bar {
+	b.AppendFunc(func(b *Bar) string {
+		return strutil.Resize(b.WorkerString(), uint(width))
+	})
+	return b
+}
+
+// PrependWorker prepends the worker id and current item to the bar, resized to width
+func (b *Bar) PrependWorker(width int) *Bar {
+	b.PrependFunc(func(b *Bar) string {
+		return strutil.Resize(b.WorkerString(), uint(width))
+	})
+	return b
+}
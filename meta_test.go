@@ -0,0 +1,19 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrependWorker(t *testing.T) {
+	b := NewBar(10).PrependWorker(5)
+	b.SetMeta(MetaWorker, 3)
+	if !strings.HasPrefix(b.String(), "W3    [") {
+		t.Fatal("want", "W3    [", "in", b.String())
+	}
+
+	b.SetMeta(MetaItem, "file.txt")
+	if !strings.HasPrefix(b.String(), "W3... [") {
+		t.Fatal("want", "W3... [", "in", b.String())
+	}
+}
@@ -0,0 +1,65 @@
+package uiprogress
+
+import (
+	"math"
+	"time"
+)
+
+// drawnState is the state of a bar in the last frame, for SetMinChange
+type drawnState struct {
+	percent float64
+	failed  bool
+}
+
+// SetMinChange skips frames until the completion of a bar changed by at least delta since it was last drawn, from
+// 0.0 to 1.0, or MaxRefreshInterval passed when it is positive. Slow jobs are redrawn far less often this way.
+// Bars being added, removed, completed or failed and indeterminate bars are always drawn. A delta of 0 draws every
+// change.
+func (p *Progress) SetMinChange(delta float64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.minChange = delta
+	p.drawn = nil
+}
+
+// changedEnough reports whether the bars changed enough since the last frame to draw another. The caller must hold
+// the lock.
+func (p *Progress) changedEnough() bool {
+	if p.minChange <= 0 || p.dirty || len(p.drawn) != len(p.Bars) {
+		return true
+	}
+	if p.MaxRefreshInterval > 0 && p.clock.Now().Sub(p.drawnAt) >= p.MaxRefreshInterval {
+		return true
+	}
+	for _, bar := range p.Bars {
+		prev, ok := p.drawn[bar]
+		if !ok || bar.animated() {
+			return true
+		}
+		now := bar.drawnState()
+		if now.failed != prev.failed || (now.percent >= 100) != (prev.percent >= 100) {
+			return true
+		}
+		if math.Abs(now.percent-prev.percent) >= p.minChange*100 {
+			return true
+		}
+	}
+	return false
+}
+
+// recordDrawn records the state of the bars drawn at t. The caller must hold the lock.
+func (p *Progress) recordDrawn(t time.Time) {
+	if p.minChange <= 0 {
+		return
+	}
+	p.drawn = make(map[*Bar]drawnState, len(p.Bars))
+	for _, bar := range p.Bars {
+		p.drawn[bar] = bar.drawnState()
+	}
+	p.drawnAt = t
+}
+
+// drawnState returns the state of the bar compared across frames
+func (b *Bar) drawnState() drawnState {
+	return drawnState{percent: b.CompletedPercent(), failed: b.Err() != nil}
+}
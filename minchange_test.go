@@ -0,0 +1,54 @@
+package uiprogress
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetMinChange(t *testing.T) {
+	out := &syncBuffer{}
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.Out = out
+	p.lw.setOut(out)
+	p.clock = clk
+	p.RefreshInterval = time.Millisecond
+	p.MaxRefreshInterval = time.Second
+	bar := p.AddBar(1000)
+	p.SetMinChange(0.01)
+
+	frames := 0
+	p.OnAfterRender(func(RenderStats) { frames++ })
+	step := func(n int) {
+		bar.Set(n)
+		p.tick(nil)
+	}
+
+	step(0)
+	for n := 1; n <= 10; n++ {
+		step(n)
+	}
+	if frames != 2 {
+		t.Fatal("want", 2, "frames for the first bar state and the 1 percent change, got", frames)
+	}
+	step(15)
+	if frames != 2 {
+		t.Fatal("want frame under the threshold skipped, got", frames)
+	}
+	clk.Sleep(time.Second)
+	step(15)
+	if frames != 3 {
+		t.Fatal("want frame once MaxRefreshInterval passed, got", frames)
+	}
+	bar.SetError(errors.New("boom"))
+	p.tick(nil)
+	if frames != 4 {
+		t.Fatal("want frame on failure, got", frames)
+	}
+	p.AddBar(10)
+	p.tick(nil)
+	if frames != 5 {
+		t.Fatal("want frame on added bar, got", frames)
+	}
+}
@@ -0,0 +1,80 @@
+package uiprogress
+
+// ChildIndent is the indentation of child bars under their parent
+var ChildIndent = "  "
+
+// AddChild creates a bar nested under b and rendered indented below it. Once b has children, its current value is
+// the average completion of the children weighted by their weight, scaled to the total of b.
+func (b *Bar) AddChild(total int, weight float64) *Bar {
+	child := NewBar(total)
+	b.mtx.Lock()
+	child.Width = b.Width
+	child.theme = b.theme
+	child.clock = b.clock
+	child.changed = b.changed
+	child.parent = b
+	child.weight = weight
+	b.children = append(b.children, child)
+	b.mtx.Unlock()
+
+	b.aggregate()
+	return child
+}
+
+// Children returns the bars nested under b
+func (b *Bar) Children() []*Bar {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return append([]*Bar(nil), b.children...)
+}
+
+// Parent returns the bar b is nested under, or nil
+func (b *Bar) Parent() *Bar {
+	return b.parent
+}
+
+// aggregate sets the current value of b from the completion of its children. It must be called without the lock held.
+func (b *Bar) aggregate() {
+	b.mtx.RLock()
+	children, total := b.children, b.Total64()
+	b.mtx.RUnlock()
+	if total <= 0 {
+		return
+	}
+
+	var weights, done float64
+	for _, child := range children {
+		weights += child.weight
+		done += child.weight * child.CompletedPercent() / 100
+	}
+	if weights > 0 {
+		b.Set64(int64(done / weights * float64(total)))
+	}
+}
+
+// treeLines renders b and, indented below it, its children. WidthAuto bars fill cols cells, when known, and bars
+// are rendered compact when cols is below CompactWidth. Completed bars are rendered according to their OnCompleteStyle.
+func (b *Bar) treeLines(indent string, cols int) []string {
+	b.mtx.RLock()
+	style, message, done := b.OnCompleteStyle, b.CompleteMessage, b.completed()
+	b.mtx.RUnlock()
+	if done && style == CompleteClear {
+		return nil
+	}
+	if done && style == CompleteReplace {
+		return []string{indent + message}
+	}
+
+	if b.Width == WidthAuto && cols > 0 {
+		b.setLineWidth(cols)
+	}
+	line := b.String()
+	if cols > 0 && cols < CompactWidth {
+		line = b.CompactString()
+	}
+	lines := []string{indent + line}
+	for _, child := range b.Children() {
+		lines = append(lines, child.treeLines(indent+ChildIndent, cols-len(ChildIndent))...)
+	}
+	return lines
+}
@@ -0,0 +1,29 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddChild(t *testing.T) {
+	p := New()
+	parent := p.AddBar(10)
+	small, large := parent.AddChild(4, 1), parent.AddChild(4, 3)
+
+	large.Set(4)
+	if got := parent.Current(); got != 7 {
+		t.Fatal("want", 7, "got", got)
+	}
+	small.Set(4)
+	if !parent.Completed() {
+		t.Fatal("want", "parent completed with its children", "got", parent.Current())
+	}
+
+	lines := p.Lines()
+	if len(lines) != 3 {
+		t.Fatal("want", 3, "lines", "got", len(lines))
+	}
+	if strings.HasPrefix(lines[0], ChildIndent) || !strings.HasPrefix(lines[1], ChildIndent+"[") {
+		t.Fatal("want", "children indented under the parent", "got", lines)
+	}
+}
@@ -0,0 +1,61 @@
+package uiprogress
+
+// BarOption configures a bar before it is added to a progress container
+type BarOption func(b *Bar)
+
+// WithWidth sets the width of the bar
+func WithWidth(width int) BarOption {
+	return func(b *Bar) {
+		b.Width = width
+	}
+}
+
+// WithName sets the name of the bar
+func WithName(name string) BarOption {
+	return func(b *Bar) {
+		b.name = name
+	}
+}
+
+// WithTheme sets the theme of the bar
+func WithTheme(t Theme) BarOption {
+	return func(b *Bar) {
+		b.theme = &t
+	}
+}
+
+// WithPrepend prepends the decorator function to the bar
+func WithPrepend(f DecoratorFunc) BarOption {
+	return func(b *Bar) {
+		b.addFunc(f, true)
+	}
+}
+
+// WithAppend appends the decorator function to the bar
+func WithAppend(f DecoratorFunc) BarOption {
+	return func(b *Bar) {
+		b.addFunc(f, false)
+	}
+}
+
+// WithWrap makes the bar wrap around to 0 past its total, see Bar.Wrap
+func WithWrap() BarOption {
+	return func(b *Bar) {
+		b.Wrap = true
+	}
+}
+
+// WithOnCompleteStyle sets how the bar is rendered once it completes, see Bar.SetOnCompleteStyle
+func WithOnCompleteStyle(style CompleteStyle, message string) BarOption {
+	return func(b *Bar) {
+		b.OnCompleteStyle = style
+		b.CompleteMessage = message
+	}
+}
+
+// WithOverflowPolicy sets what the bar does when it is set or incremented past its total, see Bar.SetOverflowPolicy
+func WithOverflowPolicy(policy OverflowPolicy) BarOption {
+	return func(b *Bar) {
+		b.overflow = policy
+	}
+}
@@ -0,0 +1,26 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBarOptions(t *testing.T) {
+	p := New()
+	theme := DefaultTheme()
+	theme.Fill = '#'
+	bar := p.AddBar(10,
+		WithWidth(20),
+		WithName("x"),
+		WithTheme(theme),
+		WithPrepend(func(b *Bar) string { return b.Name() }),
+		WithAppend(func(*Bar) string { return "end" }),
+	)
+	bar.Set(10)
+	if got, want := bar.String(), "x ["+strings.Repeat("#", 18)+"] end"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	if p.Bar("x") != bar {
+		t.Fatal("want", "bar named x")
+	}
+}
@@ -0,0 +1,54 @@
+package uiprogress
+
+// AddOverallBar creates a bar tracking the combined progress of the other bars and adds it to the default
+// progress container
+func AddOverallBar(opts ...BarOption) *Bar {
+	return defaultProgress.AddOverallBar(opts...)
+}
+
+// AddOverallBar creates a bar tracking the combined progress of the other bars of the container and adds it, for
+// a single "Total" line. Each bar weighs in proportionally to its total: the overall bar counts the current values
+// of the bars towards the sum of their totals. Indeterminate bars are left out.
+func (p *Progress) AddOverallBar(opts ...BarOption) *Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	bar := p.newBar(0, opts)
+	bar.overall = true
+	p.addBar(bar)
+	return bar
+}
+
+// updateOverall sets the overall bars of the container to the combined progress of the other bars
+func (p *Progress) updateOverall() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	p.mtx.RUnlock()
+
+	var overall []*Bar
+	var current, total int64
+	for _, bar := range bars {
+		bar.mtx.RLock()
+		isOverall, n, max := bar.overall, bar.current, bar.Total64()
+		bar.mtx.RUnlock()
+		switch {
+		case isOverall:
+			overall = append(overall, bar)
+		case max > 0:
+			if n > max {
+				n = max
+			}
+			current += n
+			total += max
+		}
+	}
+	for _, bar := range overall {
+		bar.mtx.RLock()
+		changed := bar.current != current || bar.Total64() != total
+		bar.mtx.RUnlock()
+		if changed {
+			bar.SetTotal64(total)
+			bar.Set64(current)
+		}
+	}
+}
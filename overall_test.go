@@ -0,0 +1,25 @@
+package uiprogress
+
+import "testing"
+
+func TestAddOverallBar(t *testing.T) {
+	p := New()
+	p.RefreshInterval = 0
+	p.RenderFunc = func([]string) {}
+	overall := p.AddOverallBar()
+	small, large := p.AddBar(10), p.AddBar(30)
+	p.AddBar(0)
+
+	small.Set(10)
+	large.Set(6)
+	p.tick(nil)
+	if got := overall.CompletedPercent(); got != 40 {
+		t.Fatal("want", 40, "got", got)
+	}
+
+	large.Set(30)
+	p.tick(nil)
+	if !overall.Completed() {
+		t.Fatal("want", "overall bar completed with the other bars", "got", overall.CompletedPercent())
+	}
+}
@@ -0,0 +1,32 @@
+package uiprogress
+
+// OverflowPolicy is what a bar does when it is set or incremented past its total
+type OverflowPolicy int
+
+const (
+	// OverflowClamp stops increments at the total, Set returns ErrMaxCurrentReached. It is the default.
+	OverflowClamp OverflowPolicy = iota
+
+	// OverflowExtend grows the total to the new current value
+	OverflowExtend
+
+	// OverflowError leaves the current value unchanged, Set returns ErrMaxCurrentReached, Incr and IncrBy return false
+	// and proxy readers and writers return ErrMaxCurrentReached
+	OverflowError
+)
+
+// SetOverflowPolicy sets what the bar does when it is set or incremented past its total, for work that routinely
+// exceeds its estimated total
+func (b *Bar) SetOverflowPolicy(policy OverflowPolicy) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.overflow = policy
+	return b
+}
+
+// overflowPolicy returns the overflow policy of the bar
+func (b *Bar) overflowPolicy() OverflowPolicy {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.overflow
+}
@@ -0,0 +1,33 @@
+package uiprogress
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestOverflowPolicy(t *testing.T) {
+	b := NewBar(10)
+	b.Set(8)
+	if !b.IncrBy(5) || b.Current() != 10 {
+		t.Fatal("want", "clamped at 10", "got", b.Current())
+	}
+
+	b = NewBar(10).SetOverflowPolicy(OverflowExtend)
+	b.Set(8)
+	b.IncrBy(5)
+	if err := b.Set(20); err != nil || b.Current() != 20 || b.Total != 20 {
+		t.Fatal("want", "total extended to 20", "got", b.Current(), b.Total, err)
+	}
+
+	b = NewBar(10).SetOverflowPolicy(OverflowError)
+	b.Set(8)
+	if b.IncrBy(5) || b.Current() != 8 {
+		t.Fatal("want", "increment refused", "got", b.Current())
+	}
+	_, err := io.Copy(ioutil.Discard, b.NewProxyReader(strings.NewReader("xxxxx")))
+	if err != ErrMaxCurrentReached {
+		t.Fatal("want", ErrMaxCurrentReached, "got", err)
+	}
+}
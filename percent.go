@@ -0,0 +1,42 @@
+package uiprogress
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PercentFormatter formats a completed percent with the number of decimal digits
+type PercentFormatter func(percent float64, precision int) string
+
+// DefaultPercentFormat formats the percent right aligned with a '.' decimal point, for example " 42%" or " 42.37%"
+func DefaultPercentFormat(percent float64, precision int) string {
+	width := 3
+	if precision > 0 {
+		width += precision + 1
+	}
+	return fmt.Sprintf("%*.*f%%", width, precision, percent)
+}
+
+// FrenchPercentFormat formats the percent with a ',' decimal point and a space before the percent sign, for example
+// "42 %" or "42,37 %"
+func FrenchPercentFormat(percent float64, precision int) string {
+	return strings.Replace(fmt.Sprintf("%.*f", precision, percent), ".", ",", 1) + " %"
+}
+
+// SetPercentPrecision sets the number of decimal digits the completed percent is rendered with
+func (b *Bar) SetPercentPrecision(digits int) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.percentPrecision = digits
+	b.markDirty()
+	return b
+}
+
+// SetPercentFormat sets the function formatting the completed percent, DefaultPercentFormat when f is nil
+func (b *Bar) SetPercentFormat(f PercentFormatter) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.percentFormat = f
+	b.markDirty()
+	return b
+}
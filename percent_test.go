@@ -0,0 +1,23 @@
+package uiprogress
+
+import "testing"
+
+func TestPercentFormat(t *testing.T) {
+	b := NewBar(10000)
+	b.Set(4237)
+	if got := b.CompletedPercentString(); got != " 42%" {
+		t.Fatal("want", " 42%", "got", got)
+	}
+	b.SetPercentPrecision(2)
+	if got := b.CompletedPercentString(); got != " 42.37%" {
+		t.Fatal("want", " 42.37%", "got", got)
+	}
+	b.SetPercentFormat(FrenchPercentFormat)
+	if got := b.CompletedPercentString(); got != "42,37 %" {
+		t.Fatal("want", "42,37 %", "got", got)
+	}
+	b.SetPercentPrecision(0)
+	if got := b.CompletedPercentString(); got != "42 %" {
+		t.Fatal("want", "42 %", "got", got)
+	}
+}
@@ -0,0 +1,32 @@
+package uiprogress
+
+import "time"
+
+// SetStartTime sets when the work of the bar started, for work begun before the bar was created or resumed from an
+// earlier run. The time elapsed and the average rate are measured from t.
+func (b *Bar) SetStartTime(t time.Time) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.TimeStarted.IsZero() {
+		b.startCurrent = b.current
+	}
+	b.TimeStarted = t
+	b.timeElapsed = b.timeSource().Now().Sub(t)
+	b.markDirty()
+	return b
+}
+
+// ResetTimer starts a new phase of the work of the bar, like verifying after downloading: the time elapsed, the rate
+// and the ETA are measured again from now and from the current value, which can be set back for the new phase.
+func (b *Bar) ResetTimer() *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	now := b.timeSource().Now()
+	b.TimeStarted = now
+	b.startCurrent = b.current
+	b.timeElapsed = 0
+	b.rate, b.rateUnits, b.rateSampled = 0, 0, now
+	b.rates = nil
+	b.markDirty()
+	return b
+}
@@ -0,0 +1,39 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetStartTime(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(100, 0)}
+	bar := NewBar(100).SetClock(clk)
+	bar.SetStartTime(time.Unix(90, 0))
+	bar.Set(20)
+	if got := bar.TimeElapsed(); got != time.Second*10 {
+		t.Fatal("want", time.Second*10, "got", got)
+	}
+	if got := bar.Rate(); got != 2 {
+		t.Fatal("want", 2, "got", got)
+	}
+}
+
+func TestResetTimer(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	bar := NewBar(100).SetClock(clk)
+	bar.Set(0)
+	clk.Sleep(time.Second * 50)
+	bar.Set(100)
+
+	// a second phase, verifying what was downloaded
+	bar.ResetTimer()
+	bar.Set(0)
+	clk.Sleep(time.Second * 5)
+	bar.Set(50)
+	if got := bar.TimeElapsed(); got != time.Second*5 {
+		t.Fatal("want", time.Second*5, "got", got)
+	}
+	if got := bar.ETA(); got != time.Second*5 {
+		t.Fatal("want", time.Second*5, "got", got)
+	}
+}
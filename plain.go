@@ -0,0 +1,57 @@
+package uiprogress
+
+import (
+	"os"
+	"strconv"
+)
+
+// getenv reads the environment, replaceable in tests
+var getenv = os.Getenv
+
+// SetPlainMode makes the progress print a plain line per PlainStep each bar completes, without cursor movement or
+// colors, instead of redrawing the bars. It overrides the detection on Start, which turns plain mode on when Out is a
+// file that isn't a terminal, or when TERM is dumb or CI is set, so CI logs show readable output.
+func (p *Progress) SetPlainMode(on bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.plainSet, p.plainMode = true, on
+	p.setPlain(p.wantPlain())
+}
+
+// setPlain switches a running progress between redrawing the bars and printing them as plain lines.
+// The caller must hold the lock.
+func (p *Progress) setPlain(on bool) {
+	if !p.running || p.plain == on {
+		return
+	}
+	if on && !p.paused && p.renderer() == nil {
+		p.clearFrame()
+		p.releaseOutput()
+	}
+	p.plain = on
+	if !on && p.renderer() == nil {
+		p.arb = claim(p)
+	}
+	p.notifyChange()
+}
+
+// wantPlain reports whether the bars must be printed as plain lines: in accessible mode, when set with SetPlainMode,
+// or when detected on the output. The caller must hold the lock.
+func (p *Progress) wantPlain() bool {
+	if p.announceEvery > 0 {
+		return true
+	}
+	if p.plainSet {
+		return p.plainMode
+	}
+	return p.plainDetected
+}
+
+// plainEnv reports whether the environment asks for plain output: a dumb terminal or a CI run
+func plainEnv() bool {
+	if getenv("TERM") == "dumb" {
+		return true
+	}
+	ci, _ := strconv.ParseBool(getenv("CI"))
+	return ci
+}
@@ -0,0 +1,44 @@
+package uiprogress
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPlainEnv(t *testing.T) {
+	defer func() { getenv = os.Getenv }()
+	env := map[string]string{}
+	getenv = func(key string) string { return env[key] }
+	if plainEnv() {
+		t.Fatal("want", false, "got", true)
+	}
+	env["CI"] = "true"
+	if !plainEnv() {
+		t.Fatal("want", "plain on CI", "got", false)
+	}
+	env["CI"], env["TERM"] = "", "dumb"
+	if !plainEnv() {
+		t.Fatal("want", "plain on a dumb terminal", "got", false)
+	}
+}
+
+func TestSetPlainMode(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.SetPlainMode(true)
+	p.mtx.Lock()
+	p.attachOutput()
+	plain := p.plain
+	p.mtx.Unlock()
+	if !plain {
+		t.Fatal("want", "plain mode forced", "got", plain)
+	}
+	p.Bars[0].AppendFunc(func(*Bar) string { return ColorGreen.Paint("ok") })
+	p.mtx.Lock()
+	p.render()
+	p.mtx.Unlock()
+	if s := out.String(); strings.Contains(s, "\x1b") || !strings.HasSuffix(s, "ok\n") {
+		t.Fatalf("want a plain line, got %q", s)
+	}
+}
@@ -0,0 +1,69 @@
+package uiprogress
+
+import "sync"
+
+// Pool runs tasks with a bar each in a progress container, a limited number at a time
+type Pool struct {
+	p    *Progress
+	sem  chan struct{}
+	wg   sync.WaitGroup
+	mtx  *sync.Mutex
+	errs []error
+}
+
+// NewPool returns a pool running up to concurrency tasks at a time with their bars in p. A concurrency of 0 or less
+// runs every task at once.
+func NewPool(p *Progress, concurrency int) *Pool {
+	pool := &Pool{p: p, mtx: &sync.Mutex{}}
+	if concurrency > 0 {
+		pool.sem = make(chan struct{}, concurrency)
+	}
+	return pool
+}
+
+// Go adds a bar with the name and total, and runs fn with it once the pool has room. The bar completes when fn
+// returns nil, even with a total of 0, and fails with the error otherwise.
+func (pool *Pool) Go(name string, total int, fn func(b *Bar) error) *Bar {
+	bar := pool.p.AddNamedBar(name, total)
+	pool.wg.Add(1)
+	go func() {
+		defer pool.wg.Done()
+		if pool.sem != nil {
+			pool.sem <- struct{}{}
+			defer func() { <-pool.sem }()
+		}
+
+		if err := fn(bar); err != nil {
+			bar.SetError(err)
+			pool.mtx.Lock()
+			pool.errs = append(pool.errs, err)
+			pool.mtx.Unlock()
+			return
+		}
+		total := bar.Total64()
+		if total <= 0 {
+			// an indeterminate bar finishes at the count it reached
+			total = bar.Current64()
+		}
+		bar.Finish64(total)
+	}()
+	return bar
+}
+
+// Wait waits for all the tasks to return and returns the first error, if any
+func (pool *Pool) Wait() error {
+	pool.wg.Wait()
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	if len(pool.errs) > 0 {
+		return pool.errs[0]
+	}
+	return nil
+}
+
+// Errors returns the errors of the tasks that failed so far, in the order they failed
+func (pool *Pool) Errors() []error {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	return append([]error(nil), pool.errs...)
+}
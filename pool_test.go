@@ -0,0 +1,63 @@
+package uiprogress
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	p := New()
+	pool := NewPool(p, 2)
+	failure := errors.New("failed")
+	var running, peak int32
+	for i := 0; i < 6; i++ {
+		i := i
+		pool.Go("task", 10, func(b *Bar) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				old := atomic.LoadInt32(&peak)
+				if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+					break
+				}
+			}
+			b.IncrBy(5)
+			if i == 3 {
+				return failure
+			}
+			return nil
+		})
+	}
+
+	if err := pool.Wait(); err != failure || len(pool.Errors()) != 1 {
+		t.Fatal("want", failure, "got", err, pool.Errors())
+	}
+	if peak > 2 {
+		t.Fatal("want", "at most 2 tasks at a time", "got", peak)
+	}
+	for i, bar := range p.Bars {
+		if (i == 3) != (bar.Err() != nil) || (i != 3) != bar.Completed() {
+			t.Fatal("want", "failed task 3 and the others completed", "got", i, bar.Err(), bar.Current())
+		}
+	}
+}
+
+func TestPoolZeroTotal(t *testing.T) {
+	p := New()
+	pool := NewPool(p, 1)
+	empty := pool.Go("empty", 0, func(b *Bar) error { return nil })
+	counted := pool.Go("counted", 0, func(b *Bar) error {
+		b.IncrBy(3)
+		return nil
+	})
+	if err := pool.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if !empty.Completed() || empty.CompletedPercent() != 100 {
+		t.Fatal("want", "task with a total of 0 completed", "got", empty.CompletedPercent())
+	}
+	if !counted.Completed() || counted.Current() != 3 || counted.Total64() != 3 {
+		t.Fatal("want", "indeterminate task completed at 3", "got", counted.Current(), counted.Total64())
+	}
+}
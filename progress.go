@@ -1,20 +1,16 @@
 package uiprogress
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
-	"os/signal"
-	"runtime"
-	"strconv"
+	"sort"
 	"sync"
-	"syscall"
 	"time"
 
-	"github.com/gosuri/uilive"
+	"github.com/gosuri/uiprogress/util/strutil"
 )
 
 // Out is the default writer to render progress bars to
@@ -23,34 +19,191 @@ var Out = os.Stdout
 // RefreshInterval in the default time duration to wait for refreshing the output
 var RefreshInterval = time.Millisecond * 10
 
+// MaxRefreshInterval is the default upper bound the refresh interval backs off to while no bar changes
+var MaxRefreshInterval = time.Millisecond * 500
+
+// HiddenFormat is the format of the line ending a frame clipped to the terminal height, given the number of lines left
+// out
+var HiddenFormat = "+%d hidden"
+
+// PlainStep is the default completion percent a bar must advance by before it is printed again in plain mode
+var PlainStep = 5.0
+
+// latencyFactor is how many times the time taken to write a frame the refresh interval is kept above,
+// so that drawing doesn't flood a slow output
+const latencyFactor = 4
+
+// idleIntervals is the number of refresh intervals without changes before the refresh interval backs off
+const idleIntervals = 10
+
 // defaultProgress is the default progress
 var defaultProgress = New()
 
-//split token to get size of terminal
+// SizeToken is the split token used to parse the output of stty.
+//
+// Deprecated: the terminal size is read with a system call and SizeToken is no longer used.
 var SizeToken = byte(' ')
 
+// ErrNoOutput is returned by Start when the progress has no output writer
+var ErrNoOutput = errors.New("errors: no output writer")
+
+// ErrExecFail is error when the size of the terminal cannot be detected
 var ErrExecFail = errors.New("errors: fail to get terminal width")
 
+// Sizer is the default TerminalSizer used to detect the width of the terminal. It caches the size until the terminal
+// is resized.
+var Sizer TerminalSizer = NewCachedSizer(terminalSizer{})
+
+var (
+	// active is the set of started progress containers, used by PauseAll and ResumeAll
+	active    = make(map[*Progress]struct{})
+	activeMtx = &sync.Mutex{}
+)
+
 // Progress represents the container that renders progress bars
 type Progress struct {
 	// Out is the writer to render progress bars to
 	Out io.Writer
 
-	// Width is the width of the progress bars
+	// Width is the width of the progress bars, or WidthAuto for the bars to fill the terminal width
 	Width int
 
 	// Bars is the collection of progress bars
 	Bars []*Bar
 
-	// RefreshInterval in the time duration to wait for refreshing the output
+	// RefreshInterval in the time duration to wait for refreshing the output. Frames are drawn when bars change,
+	// at most once per RefreshInterval, with updates in between coalesced into the next frame.
 	RefreshInterval time.Duration
 
-	//channel for sigwinch to change width
-	sigChan chan os.Signal
+	// Sizer detects the width of the terminal when it is resized
+	Sizer TerminalSizer
+
+	// MaxRefreshInterval is the upper bound the refresh interval doubles up to while no bar changes.
+	// Backoff is disabled when it is not greater than RefreshInterval. When it is negative, nothing is redrawn
+	// while no bar changes, unless an indeterminate bar needs animating.
+	MaxRefreshInterval time.Duration
+
+	// PlainStep is the completion percent a bar must advance by before it is printed again, when the output is not
+	// a terminal and bars are printed as plain lines instead of being redrawn
+	PlainStep float64
+
+	// Log, when set, receives a timestamped plain line each time a bar completes another LogStep percent,
+	// in addition to the rendering to Out. A log file keeps a record of the progress this way.
+	Log io.Writer
+
+	// LogStep is the completion percent a bar must advance by before it is written to Log again
+	LogStep float64
+
+	// Theme is the theme of the bars added to the container, when set
+	Theme *Theme
+
+	// SortBy orders the bars on each render when set. The order of Bars is not changed.
+	SortBy SortFunc
+
+	// MaxVisibleBars limits the number of bars rendered when greater than 0. The incomplete and most recently updated
+	// bars are shown, followed by a summary line of the others.
+	MaxVisibleBars int
+
+	// Emitter, when set, publishes an event for each bar update on every refresh, in addition to rendering
+	Emitter Emitter
+
+	// RenderFunc, when set, receives the bar lines on each refresh instead of them being written to Out,
+	// so the caller can compose the lines into its own live output
+	RenderFunc func(lines []string)
+
+	// Renderer, when set, receives the bar lines on each refresh like RenderFunc, for embedding the bars in another
+	// user interface. It takes precedence over RenderFunc.
+	Renderer Renderer
+
+	// Debug appends a line of render diagnostics after the bars, for tuning RefreshInterval: the frames rendered
+	// per second, the time taken to write a frame, the bytes of the last frame and the bar updates dropped by
+	// coalescing them into a frame
+	Debug bool
+
+	// StderrFallback renders the bars to stderr instead when Out is stdout and stdout is redirected while stderr is
+	// a terminal, so progress is still shown when the output of the program is piped
+	StderrFallback bool
+
+	// resizeChan receives a value whenever the terminal is resized
+	resizeChan chan struct{}
+	// contChan receives a value whenever the process resumes after being suspended
+	contChan chan struct{}
+	// groups are the sections of bars, rendered after the other bars
+	groups []*Group
+	// latency is the smoothed time taken to write a frame
+	latency time.Duration
+	// columns are the columns of the bars added to the container, when set
+	columns []Column
+	// stats are the render diagnostics of Debug
+	stats renderStats
+	// cols is the width of the terminal, for the bars with WidthAuto to fill
+	cols int
+	// rows is the height of the terminal, frames are clipped to fit it
+	rows int
+	// compact renders the bars as a single summary line
+	compact bool
+	// changes receives a value whenever a bar changes
+	changes  chan struct{}
+	watching bool
+	// running is set from Start until Stop
+	running bool
+	// done is closed to unblock the callers of Wait
+	done chan struct{}
+	// beforeRender and afterRender are called around each frame of the render loop when set
+	beforeRender func()
+	afterRender  func(stats RenderStats)
 
-	lw       *uilive.Writer
+	lw       *frameWriter
 	stopChan chan struct{}
-	mtx      *sync.RWMutex
+	// loops counts the render loops running, for Stop to wait for them to exit
+	loops  *sync.WaitGroup
+	paused bool
+	nextID int
+	mtx    *sync.RWMutex
+
+	clock Clock
+	// dirty is set when bars are removed, for the backoff to treat it as a change
+	dirty bool
+	// backoff is the number of times the refresh interval has been doubled since the last change
+	backoff    uint
+	lastChange time.Time
+
+	// plain renders the bars as plain lines without ANSI escape sequences, for terminals that can't interpret them
+	plain bool
+	// plainMode is the plain mode set with SetPlainMode, used instead of the detected one when plainSet
+	plainMode, plainSet bool
+	// plainDetected is set when the output was detected to not interpret ANSI escape sequences
+	plainDetected bool
+	// plainSteps holds the last PlainStep each bar was printed at in plain mode
+	plainSteps map[*Bar]int
+	// announceEvery is the cadence of the announcements in accessible mode, which is on when it is greater than 0
+	announceEvery time.Duration
+	// lastAnnounce is when the bars were last announced
+	lastAnnounce time.Time
+	// announced holds the last announcement of each bar
+	announced map[*Bar]announcement
+	// logSteps holds the last LogStep each bar was written to Log at
+	logSteps map[*Bar]int
+	// emitted holds the last event published for each bar
+	emitted map[*Bar]Event
+	// minChange is the completion a bar must change by for a frame to be drawn, drawn holds the state of each bar
+	// in the last frame, drawnAt when it was drawn
+	minChange float64
+	drawn     map[*Bar]drawnState
+	drawnAt   time.Time
+	// broadcasters receive each frame of the render loop, frame holds the lines of the last frame render drew
+	broadcasters []*Broadcaster
+	frame        []string
+	// renderBudget is the number of bars rebuilt per frame when greater than 0, starting from the bar at budgetNext
+	renderBudget int
+	budgetNext   int
+	// carriageReturn redraws a single line in place, lineWidth being the width of the line drawn, 0 when there is none
+	carriageReturn bool
+	lineWidth      int
+	// arb renders the bars in a region of the terminal shared with other containers, when Out is a terminal
+	arb *arbiter
+	// restoreConsole restores the terminal state changed on Start
+	restoreConsole func()
 }
 
 // New returns a new progress bar with defaults
@@ -60,25 +213,68 @@ func New() *Progress {
 		Out:             Out,
 		Bars:            make([]*Bar, 0),
 		RefreshInterval: RefreshInterval,
+		Sizer:           Sizer,
+		PlainStep:       PlainStep,
+		LogStep:         PlainStep,
+		StderrFallback:  StderrFallback,
+
+		MaxRefreshInterval: MaxRefreshInterval,
 
-		sigChan:  make(chan os.Signal, 1),
-		lw:       uilive.New(),
-		stopChan: make(chan struct{}),
-		mtx:      &sync.RWMutex{},
+		resizeChan: make(chan struct{}, 1),
+		contChan:   make(chan struct{}, 1),
+		changes:    make(chan struct{}, 1),
+		lw:         newFrameWriter(),
+		stopChan:   make(chan struct{}),
+		loops:      &sync.WaitGroup{},
+		mtx:        &sync.RWMutex{},
+		clock:      realClock{},
 	}
 }
 
-// AddBar creates a new progress bar and adds it to the default progress container
-func AddBar(total int) *Bar {
-	return defaultProgress.AddBar(total)
+// AddBar creates a new progress bar configured with the options and adds it to the default progress container
+func AddBar(total int, opts ...BarOption) *Bar {
+	return defaultProgress.AddBar(total, opts...)
+}
+
+// AddBar64 creates a new progress bar with an int64 total and adds it to the default progress container
+func AddBar64(total int64, opts ...BarOption) *Bar {
+	return defaultProgress.AddBar64(total, opts...)
+}
+
+// Default returns the default progress container, used by the package-level functions
+func Default() *Progress {
+	return defaultProgress
+}
+
+// SetOutput sets the writer the bars of the default progress container are rendered to, see Progress.SetOutput.
+// Unlike changing Out, it takes effect on a container already created or started.
+func SetOutput(w io.Writer) error {
+	return defaultProgress.SetOutput(w)
+}
+
+// SetRefreshInterval sets the refresh interval of the default progress container, see Progress.SetMinInterval
+func SetRefreshInterval(d time.Duration) {
+	defaultProgress.SetMinInterval(d)
+}
+
+// SetWidth sets the width of the bars of the default progress container, see Progress.SetWidth
+func SetWidth(width int) {
+	defaultProgress.SetWidth(width)
 }
 
 // Start starts the rendering the progress of progress bars using the DefaultProgress. It listens for updates using `bar.Set(n)` and new bars when added using `AddBar`
-func Start() {
-	defaultProgress.Start()
+func Start() error {
+	_, err := defaultProgress.Start()
+	return err
 }
 
-// Stop stops listening
+// StartWithContext starts rendering using the DefaultProgress and stops it when ctx is done
+func StartWithContext(ctx context.Context) error {
+	_, err := defaultProgress.StartWithContext(ctx)
+	return err
+}
+
+// Stop stops listening, see Progress.Stop
 func Stop() {
 	defaultProgress.Stop()
 }
@@ -88,98 +284,896 @@ func Listen() {
 	defaultProgress.Listen()
 }
 
-// AddBar creates a new progress bar and adds to the container
-func (p *Progress) AddBar(total int) *Bar {
+// PauseAll pauses rendering of all the started progress containers
+func PauseAll() {
+	activeMtx.Lock()
+	defer activeMtx.Unlock()
+	for p := range active {
+		p.Pause()
+	}
+}
+
+// ResumeAll resumes rendering of all the started progress containers
+func ResumeAll() {
+	activeMtx.Lock()
+	defer activeMtx.Unlock()
+	for p := range active {
+		p.Resume()
+	}
+}
+
+// AddBar creates a new progress bar and adds to the container. The options are applied before the bar is added,
+// so it is fully configured by the time it is first rendered.
+func (p *Progress) AddBar(total int, opts ...BarOption) *Bar {
+	return p.AddBar64(int64(total), opts...)
+}
+
+// AddBar64 creates a new progress bar with an int64 total and adds to the container
+func (p *Progress) AddBar64(total int64, opts ...BarOption) *Bar {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	bar := NewBar(total)
+	bar := p.newBar(total, opts)
+	p.addBar(bar)
+	return bar
+}
+
+// AddNamedBar creates a new progress bar with the name and adds it to the container, for Bar to look it up
+func (p *Progress) AddNamedBar(name string, total int, opts ...BarOption) *Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	bar := p.newBar(int64(total), append([]BarOption{WithName(name)}, opts...))
+	p.addBar(bar)
+	return bar
+}
+
+// Bar returns the first bar of the container with the name, or nil when there is none
+func (p *Progress) Bar(name string) *Bar {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	for _, bar := range p.Bars {
+		if bar.Name() == name {
+			return bar
+		}
+	}
+	return nil
+}
+
+// newBar creates a bar with the width and theme of the container, then applies the options. The caller must hold the lock.
+func (p *Progress) newBar(total int64, opts []BarOption) *Bar {
+	bar := NewBar64(total)
 	bar.Width = p.Width
-	p.Bars = append(p.Bars, bar)
+	if p.Theme != nil {
+		bar.SetTheme(*p.Theme)
+	}
+	if p.columns != nil {
+		bar.columns = p.columns
+	}
+	for _, opt := range opts {
+		opt(bar)
+	}
+	if bar.Width == WidthAuto && bar.lineWidth == 0 {
+		p.detectCols()
+		bar.lineWidth = p.cols
+	}
 	return bar
 }
 
+// addBar assigns the bar an id and adds it to the container. The caller must hold the lock.
+func (p *Progress) addBar(bar *Bar) {
+	bar.id = p.nextID
+	bar.changed = p.changes
+	if bar.clock == nil {
+		bar.clock = p.clock
+	}
+	p.nextID++
+	p.Bars = append(p.Bars, bar)
+	p.notifyChange()
+}
+
+// notifyChange wakes up the render loop for the next frame
+func (p *Progress) notifyChange() {
+	select {
+	case p.changes <- struct{}{}:
+	default:
+	}
+}
+
+// RemoveBar removes the bar from the container. The rendered output shrinks on the next refresh.
+// It returns false when the bar is not in the container.
+func (p *Progress) RemoveBar(bar *Bar) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for i, b := range p.Bars {
+		if b == bar {
+			p.Bars = append(p.Bars[:i], p.Bars[i+1:]...)
+			delete(p.plainSteps, bar)
+			delete(p.logSteps, bar)
+			delete(p.emitted, bar)
+			delete(p.announced, bar)
+			p.dirty = true
+			p.notifyChange()
+			return true
+		}
+	}
+	return false
+}
+
+// ClearCompleted removes all the completed bars from the container and returns the number of bars removed
+func (p *Progress) ClearCompleted() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	bars := p.Bars[:0]
+	for _, bar := range p.Bars {
+		if bar.Completed() {
+			delete(p.plainSteps, bar)
+			delete(p.logSteps, bar)
+			delete(p.emitted, bar)
+			delete(p.announced, bar)
+			continue
+		}
+		bars = append(bars, bar)
+	}
+	n := len(p.Bars) - len(bars)
+	for i := len(bars); i < len(p.Bars); i++ {
+		p.Bars[i] = nil
+	}
+	p.Bars = bars
+	if n > 0 {
+		p.dirty = true
+		p.notifyChange()
+	}
+	return n
+}
+
+// ChangeWidth sets the width of the bars to the width of the terminal
 func (p *Progress) ChangeWidth() {
-	width, err := GetTerminalWidth()
+	if p.Sizer == nil {
+		return
+	}
+	invalidate(p.Sizer)
+	cols, rows, err := p.Sizer.Size()
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
+	cols, rows = validSize(cols, rows)
+	width := barWidth(cols)
 
-	p.mtx.RLock()
+	p.mtx.Lock()
+	p.cols, p.rows = cols, rows
 	for _, bar := range p.Bars {
-		bar.SetWidth(width)
+		if bar.Width == WidthAuto {
+			bar.setLineWidth(cols)
+		} else {
+			bar.SetWidth(width)
+		}
 	}
 	p.lw.Flush()
-	p.mtx.RUnlock()
+	p.mtx.Unlock()
 }
 
-// Listen listens for updates and renders the progress bars
+// detectCols sets the terminal width for the bars with WidthAuto and the height frames are clipped to, if they aren't
+// known yet. The caller must hold the lock.
+func (p *Progress) detectCols() {
+	if p.cols > 0 || p.Sizer == nil {
+		return
+	}
+	if width, height, err := p.Sizer.Size(); err == nil {
+		p.cols, p.rows = validSize(width, height)
+	}
+}
+
+// Listen listens for updates and renders the progress bars, until Stop is called
 func (p *Progress) Listen() {
-	p.lw.Out = p.Out
+	p.mtx.Lock()
+	stopChan := p.stopChan
+	p.lw.setOut(p.Out)
+	p.mtx.Unlock()
+	p.listen(stopChan)
+}
+
+// listen renders the progress bars until stopChan is closed. Each run of the progress has its own stopChan,
+// so the loop of a stopped run can't keep going after a restart.
+func (p *Progress) listen(stopChan chan struct{}) {
+	if stopChan == nil {
+		return
+	}
+	p.mtx.Lock()
+	select {
+	case <-stopChan:
+		p.mtx.Unlock()
+		return
+	default:
+	}
+	p.loops.Add(1)
+	p.mtx.Unlock()
+	defer p.loops.Done()
+
 	for {
+		p.mtx.RLock()
+		idle, ok := p.idleInterval()
+		p.mtx.RUnlock()
+
+		var timeout <-chan time.Time
+		if ok {
+			timeout = p.clock.After(idle)
+		}
 		select {
-		case <-p.stopChan:
+		case <-stopChan:
 			return
-		case <-p.sigChan:
+		case <-p.resizeChan:
+			p.ChangeWidth()
+			p.redraw()
+		case <-p.contChan:
 			p.ChangeWidth()
-		default:
-			time.Sleep(p.RefreshInterval)
-			p.mtx.RLock()
-			for _, bar := range p.Bars {
-				fmt.Fprintln(p.lw, bar.String())
+			p.repaint()
+		case <-p.changes:
+			p.tick(stopChan)
+		case <-timeout:
+			p.tick(stopChan)
+		}
+	}
+}
+
+// redraw clears the bars and renders them again right away, after the terminal is resized
+func (p *Progress) redraw() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.paused {
+		return
+	}
+	if !p.plain && p.renderer() == nil {
+		p.clearFrame()
+	}
+	p.render()
+}
+
+// repaint draws the bars again from scratch after the process resumed from a suspension. The shell printed below
+// the previous frame meanwhile, so the frame is left where it is rather than erased, and the bars are drawn anew below.
+func (p *Progress) repaint() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.paused {
+		return
+	}
+	p.lineWidth = 0
+	if p.arb != nil {
+		p.arb.forget()
+	} else {
+		p.lw.forget()
+	}
+	p.render()
+}
+
+// tick waits for the refresh interval, coalescing the changes made meanwhile, and renders a frame. It stops waiting
+// when stopChan is closed, so Stop doesn't wait out the interval.
+func (p *Progress) tick(stopChan chan struct{}) {
+	p.mtx.RLock()
+	d := p.frameInterval()
+	p.mtx.RUnlock()
+	select {
+	case <-p.clock.After(d):
+	case <-stopChan:
+	}
+	p.reconcile()
+	p.updateTimers()
+	p.updateFuncs()
+	p.updateOverall()
+	p.checkStalls()
+	p.sampleRates()
+
+	p.mtx.RLock()
+	before, after, paused := p.beforeRender, p.afterRender, p.paused
+	p.mtx.RUnlock()
+	if before != nil && !paused {
+		before()
+	}
+
+	p.mtx.Lock()
+	var stats *RenderStats
+	if !p.paused && p.changedEnough() {
+		started := p.clock.Now()
+		p.countFrame(started)
+		p.render()
+		p.recordDrawn(started)
+		d := p.clock.Now().Sub(started)
+		p.latency = (p.latency + d) / 2
+		stats = &RenderStats{Started: started, Duration: d, Bytes: p.stats.bytes}
+		p.broadcast()
+	}
+	p.emit()
+	p.renderLog()
+	p.updateBackoff()
+	if p.done != nil && p.finished() {
+		p.releaseWaiters()
+	}
+	p.mtx.Unlock()
+
+	if after != nil && stats != nil {
+		after(*stats)
+	}
+}
+
+// refreshInterval returns the time to wait before the next frame. The caller must hold the lock.
+func (p *Progress) refreshInterval() time.Duration {
+	d := p.RefreshInterval << p.backoff
+	if d > p.MaxRefreshInterval {
+		d = p.MaxRefreshInterval
+	}
+	if d < p.RefreshInterval {
+		d = p.RefreshInterval
+	}
+	return d
+}
+
+// frameInterval returns the minimum time between frames, the refresh interval or longer when writing frames is slow.
+// The caller must hold the lock.
+func (p *Progress) frameInterval() time.Duration {
+	d := p.RefreshInterval
+	if slow := p.latency * latencyFactor; slow > d {
+		d = slow
+	}
+	if p.MaxRefreshInterval > p.RefreshInterval && d > p.MaxRefreshInterval {
+		d = p.MaxRefreshInterval
+	}
+	return d
+}
+
+// SetMinInterval sets the minimum time between frames, the refresh interval. Frames are spaced further apart when
+// writing them to Out is slow.
+func (p *Progress) SetMinInterval(d time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.RefreshInterval = d
+}
+
+// SetWidth sets the width of all the bars in the container, including the ones added later
+func (p *Progress) SetWidth(width int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.Width = width
+	for _, bar := range p.Bars {
+		bar.SetWidth(width)
+	}
+	p.notifyChange()
+}
+
+// SetMaxFPS sets the maximum number of frames drawn per second, like SetMinInterval
+func (p *Progress) SetMaxFPS(fps int) {
+	if fps > 0 {
+		p.SetMinInterval(time.Second / time.Duration(fps))
+	}
+}
+
+// idleInterval returns the time to wait for a change before redrawing anyway, on top of the refresh interval.
+// It returns false when nothing needs redrawing until a bar changes. The caller must hold the lock.
+func (p *Progress) idleInterval() (time.Duration, bool) {
+	if p.MaxRefreshInterval < 0 {
+		for _, bar := range p.Bars {
+			if bar.animated() {
+				return 0, true
 			}
-			p.lw.Flush()
-			p.mtx.RUnlock()
 		}
+		return 0, false
+	}
+	return p.refreshInterval() - p.RefreshInterval, true
+}
+
+// updateBackoff doubles the refresh interval when no bar has changed for idleIntervals and resets it on the next change.
+// The caller must hold the lock.
+func (p *Progress) updateBackoff() {
+	now := p.clock.Now()
+	changed := p.dirty
+	p.dirty = false
+	for _, bar := range p.Bars {
+		if bar.takeDirty() || bar.animated() {
+			changed = true
+		}
+	}
+	if changed {
+		p.lastChange = now
+		p.backoff = 0
+		return
+	}
+	if now.Sub(p.lastChange) >= p.RefreshInterval*idleIntervals && p.refreshInterval() < p.MaxRefreshInterval {
+		p.backoff++
+	}
+}
+
+// render writes the current state of the bars to the output. The caller must hold the lock.
+func (p *Progress) render() {
+	if p.renderBudget > 0 {
+		// the budget is spent once per frame, on the lines drawn and broadcast
+		p.holdBars()
+		defer p.releaseBars()
+	}
+	p.frame = nil
+	if r := p.renderer(); r != nil {
+		p.frame = p.lines()
+		r.Render(p.frame)
+		return
+	}
+	if p.announceEvery > 0 {
+		p.renderAnnouncements()
+		return
+	}
+	if p.plain {
+		p.renderPlain()
+		return
+	}
+	lines := p.clipRows(p.lines())
+	p.frame = lines
+	if p.carriageReturn && len(lines) == 1 {
+		p.renderLine(lines[0])
+		return
+	}
+	p.endLine()
+	if p.arb != nil {
+		p.stats.bytes = p.arb.draw(p, lines)
+		return
+	}
+	p.stats.bytes = p.lw.draw(lines, p.cols)
+}
+
+// Lines returns the rendered bars as lines, in render order
+func (p *Progress) Lines() []string {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.lines()
+}
+
+// lines returns the rendered bars in render order. The caller must hold the lock.
+func (p *Progress) lines() []string {
+	if p.compact {
+		lines := []string{p.summaryLine()}
+		if p.cols > 0 && strutil.Width(lines[0]) > p.cols {
+			lines[0] = strutil.Truncate(lines[0], p.cols)
+		}
+		if p.Debug {
+			lines = append(lines, p.debugLine())
+		}
+		return lines
+	}
+	bars := p.sortedBars()
+	visible := p.visibleBars(bars)
+	lines := make([]string, 0, len(visible)+len(p.groups)+1)
+	for _, bar := range visible {
+		if bar.group == nil {
+			lines = append(lines, bar.treeLines("", p.cols)...)
+		}
+	}
+	for _, g := range p.groups {
+		lines = append(lines, g.lines(visible, p.cols)...)
+	}
+	if p.cols > 0 {
+		// lines wider than the terminal would wrap and break the redraw
+		for i, line := range lines {
+			if strutil.Width(line) > p.cols {
+				lines[i] = strutil.Truncate(line, p.cols)
+			}
+		}
+	}
+	if hidden := len(bars) - len(visible); hidden > 0 {
+		var pct float64
+		for _, bar := range bars {
+			pct += bar.CompletedPercent()
+		}
+		lines = append(lines, fmt.Sprintf("… and %d more (%.f%% overall)", hidden, pct/float64(len(bars))))
+	}
+	if p.Debug {
+		lines = append(lines, p.debugLine())
+	}
+	return lines
+}
+
+// clipRows returns the first lines of a frame fitting the terminal height, the last one replaced by HiddenFormat with
+// the number of lines left out. A frame taller than the terminal would scroll its first lines out of reach of the
+// redraw. A row is kept for the cursor below the frame. The caller must hold the lock.
+func (p *Progress) clipRows(lines []string) []string {
+	if p.rows <= 0 {
+		return lines
+	}
+	max := p.rows - 1
+	if max < 1 {
+		max = 1
+	}
+	if len(lines) <= max {
+		return lines
+	}
+	hidden := len(lines) - max + 1
+	return append(lines[:max-1], fmt.Sprintf(HiddenFormat, hidden))
+}
+
+// visibleBars returns the bars to render when MaxVisibleBars is set, keeping their order: the incomplete bars first,
+// then the most recently updated ones.
+func (p *Progress) visibleBars(bars []*Bar) []*Bar {
+	if p.MaxVisibleBars <= 0 || len(bars) <= p.MaxVisibleBars {
+		return bars
+	}
+	ranked := make([]*Bar, len(bars))
+	copy(ranked, bars)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ci, cj := ranked[i].Completed(), ranked[j].Completed(); ci != cj {
+			return cj
+		}
+		return ranked[i].LastUpdated().After(ranked[j].LastUpdated())
+	})
+	shown := make(map[*Bar]bool, p.MaxVisibleBars)
+	for _, bar := range ranked[:p.MaxVisibleBars] {
+		shown[bar] = true
+	}
+	visible := make([]*Bar, 0, p.MaxVisibleBars)
+	for _, bar := range bars {
+		if shown[bar] {
+			visible = append(visible, bar)
+		}
+	}
+	return visible
+}
+
+// SetMaxVisibleBars limits the number of bars rendered to n, summarizing the others on one line. An n of 0 renders
+// all the bars.
+func (p *Progress) SetMaxVisibleBars(n int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.MaxVisibleBars = n
+	p.dirty = true
+	p.notifyChange()
+}
+
+// sortedBars returns the bars in render order. The caller must hold the lock.
+func (p *Progress) sortedBars() []*Bar {
+	if p.SortBy == nil {
+		return p.Bars
+	}
+	bars := make([]*Bar, len(p.Bars))
+	copy(bars, p.Bars)
+	sort.SliceStable(bars, func(i, j int) bool {
+		return p.SortBy(bars[i], bars[j])
+	})
+	return bars
+}
+
+// renderPlain prints a bar as a new line each time it completes another PlainStep percent, without moving the
+// cursor or using colors
+func (p *Progress) renderPlain() {
+	if p.plainSteps == nil {
+		p.plainSteps = make(map[*Bar]int)
+	}
+	printSteps(p.Out, p.sortedBars(), p.PlainStep, p.plainSteps, "")
+}
+
+// renderLog prints a timestamped plain line to Log for each bar that completed another LogStep percent
+func (p *Progress) renderLog() {
+	if p.Log == nil {
+		return
+	}
+	if p.logSteps == nil {
+		p.logSteps = make(map[*Bar]int)
+	}
+	printSteps(p.Log, p.sortedBars(), p.LogStep, p.logSteps, p.clock.Now().Format(time.RFC3339)+" ")
+}
+
+// printSteps prints each bar on a plain line after prefix, when its completion reached another step percent since
+// the step recorded in steps
+func printSteps(out io.Writer, bars []*Bar, step float64, steps map[*Bar]int, prefix string) {
+	for _, bar := range bars {
+		n := 0
+		if step > 0 {
+			n = int(bar.CompletedPercent() / step)
+		}
+		if last, ok := steps[bar]; ok && last == n {
+			continue
+		}
+		steps[bar] = n
+		fmt.Fprintln(out, prefix+strutil.StripANSI(bar.String()))
 	}
 }
 
 // Start starts the rendering the progress of progress bars. It listens for updates using `bar.Set(n)` and new bars when added using `AddBar`
-func (p *Progress) Start() {
+// It returns the progress for chaining, and an error when Out can't be written to, in which case nothing is rendered.
+// Starting a progress that is already started does nothing.
+func (p *Progress) Start() (*Progress, error) {
+	p.mtx.Lock()
+	if p.running {
+		p.mtx.Unlock()
+		return p, nil
+	}
+	if err := checkOutput(p.Out); err != nil {
+		p.mtx.Unlock()
+		return p, err
+	}
 	if p.stopChan == nil {
 		p.stopChan = make(chan struct{})
 	}
+	p.running = true
+	stopChan := p.stopChan
+	p.attachOutput()
+	p.detectCols()
+	p.mtx.Unlock()
+	p.WatchResize()
 
-	if p.sigChan == nil {
-		p.sigChan = make(chan os.Signal, 1)
+	activeMtx.Lock()
+	active[p] = struct{}{}
+	activeMtx.Unlock()
+
+	go p.listen(stopChan)
+	return p, nil
+}
+
+// WithOutput sets the writer the bars are rendered to like SetOutput and returns the progress for chaining
+func (p *Progress) WithOutput(w io.Writer) *Progress {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.swapOutput(w)
+	return p
+}
+
+// SetOutput sets the writer the bars are rendered to. It is safe to call while rendering, for example to redirect
+// the output when the terminal is detached: the bars are cleared from the previous writer and drawn on w from the
+// next frame. It returns an error when w can't be written to, leaving the output unchanged.
+func (p *Progress) SetOutput(w io.Writer) error {
+	if err := checkOutput(w); err != nil {
+		return err
 	}
-	p.SetNotify()
-	go p.Listen()
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.swapOutput(w)
+	return nil
 }
 
-// Stop stops listening
+// swapOutput replaces Out with w, moving the rendering over when running. The caller must hold the lock.
+func (p *Progress) swapOutput(w io.Writer) {
+	if !p.running {
+		p.Out = w
+		return
+	}
+	if !p.paused && !p.plain && p.renderer() == nil {
+		p.clearFrame()
+	}
+	if p.restoreConsole != nil {
+		p.restoreConsole()
+	}
+	p.releaseOutput()
+	p.Out = w
+	p.attachOutput()
+	p.notifyChange()
+}
+
+// attachOutput points the live writer at Out, switching Out to stderr when stdout is redirected, and detects
+// whether the bars can be redrawn on it. The caller must hold the lock.
+func (p *Progress) attachOutput() {
+	p.selectSink()
+	p.lw.setOut(p.Out)
+	restore, ok := enableANSI(p.Out)
+	p.restoreConsole = restore
+	fd, isFd := fdOf(p.Out)
+	p.plainDetected = !ok || (isFd && (!isTerminal(fd) || plainEnv()))
+	p.plain = p.wantPlain()
+	p.arb = nil
+	if !p.plain && p.renderer() == nil {
+		p.arb = claim(p)
+	}
+}
+
+// releaseOutput gives up the region of the terminal claimed by attachOutput. Frames rendered afterwards, by a tick
+// racing with Stop, only redraw the regions of the other containers. The caller must hold the lock.
+func (p *Progress) releaseOutput() {
+	if p.arb != nil {
+		p.arb.release(p)
+	}
+}
+
+// clearFrame clears the bars from the output. The caller must hold the lock.
+func (p *Progress) clearFrame() {
+	if !p.eraseLine() {
+		p.clearLines()
+	}
+}
+
+// clearLines clears the lines drawn with the cursor movement. The caller must hold the lock.
+func (p *Progress) clearLines() {
+	if p.arb != nil {
+		p.arb.draw(p, nil)
+		return
+	}
+	p.lw.Bypass().Write(nil)
+}
+
+// checkOutput returns an error when w can't be written to, like a closed file
+func checkOutput(w io.Writer) error {
+	if w == nil {
+		return ErrNoOutput
+	}
+	if f, ok := w.(interface {
+		Stat() (os.FileInfo, error)
+	}); ok {
+		if _, err := f.Stat(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartWithContext starts rendering like Start and stops when ctx is done, so callers don't have to call Stop in every exit path
+func (p *Progress) StartWithContext(ctx context.Context) (*Progress, error) {
+	if _, err := p.Start(); err != nil {
+		return p, err
+	}
+	p.mtx.RLock()
+	stopChan := p.stopChan
+	p.mtx.RUnlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.Stop()
+		case <-stopChan:
+		}
+	}()
+	return p, nil
+}
+
+// Stop stops listening. It is safe to call more than once, and the progress can be started again afterwards.
+// It waits for the render loop to exit and draws a final frame, so the latest state of the bars is shown when it
+// returns. It must not be called from the render hooks, which run on the render loop.
 func (p *Progress) Stop() {
+	activeMtx.Lock()
+	delete(active, p)
+	activeMtx.Unlock()
+
+	p.mtx.Lock()
+	if p.stopChan == nil {
+		p.mtx.Unlock()
+		return
+	}
+	running := p.running
 	close(p.stopChan)
 	p.stopChan = nil
-	close(p.sigChan)
-	p.sigChan = nil
+	p.mtx.Unlock()
+
+	// wait for the render loop to exit, so the final frame is the last one drawn
+	p.loops.Wait()
+	if running {
+		p.reconcile()
+		p.updateTimers()
+		p.updateFuncs()
+		p.updateOverall()
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if running && !p.paused {
+		// the final frame shows every bar up to date
+		budget := p.renderBudget
+		p.renderBudget = 0
+		p.render()
+		p.renderBudget = budget
+		p.emit()
+		p.renderLog()
+	}
+	if p.restoreConsole != nil {
+		p.restoreConsole()
+		p.restoreConsole = nil
+	}
+	p.endLine()
+	p.releaseOutput()
+	p.releaseWaiters()
+	p.watching = false
+	p.running = false
+}
+
+// StopWithSummary stops the progress, which renders a last frame when it was started, and prints the summary of each
+// bar to Out
+func (p *Progress) StopWithSummary() {
+	p.Stop()
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	for _, bar := range p.sortedBars() {
+		fmt.Fprintln(p.Out, bar.SummaryString())
+	}
+}
+
+// Pause suspends rendering of the progress bars and clears them from the terminal until Resume is called,
+// so other output can be written safely. Bars can still be updated while paused.
+func (p *Progress) Pause() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.paused {
+		return
+	}
+	p.paused = true
+	if !p.plain && p.renderer() == nil {
+		p.clearFrame()
+	}
+}
+
+// Resume redraws the progress bars and resumes rendering after a call to Pause
+func (p *Progress) Resume() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if !p.paused {
+		return
+	}
+	p.paused = false
+	p.render()
+}
+
+// Bypass returns a writer for printing output above the progress bars. The bars are cleared before each write
+// and redrawn after it, so log lines scroll up without breaking the display.
+func (p *Progress) Bypass() io.Writer {
+	return &bypass{p: p}
+}
+
+type bypass struct {
+	p *Progress
 }
 
-// Set Notify for syscall SIGWINCH to change width
+func (b *bypass) Write(buf []byte) (int, error) {
+	p := b.p
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.plain || p.renderer() != nil {
+		return p.Out.Write(buf)
+	}
+	if p.eraseLine() {
+		n, err := p.Out.Write(buf)
+		if !p.paused {
+			p.render()
+		}
+		return n, err
+	}
+	if p.arb != nil {
+		return p.arb.bypass(buf)
+	}
+	n, err := p.lw.Bypass().Write(buf)
+	if !p.paused {
+		p.render()
+	}
+	return n, err
+}
+
+// WatchResize changes the width of the bars whenever the terminal is resized, until Stop is called. It is called by Start.
+// Resizes are detected with SIGWINCH on Unix and by polling the console size on Windows, it does nothing without a Sizer.
+func (p *Progress) WatchResize() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.watching || p.stopChan == nil || p.Sizer == nil {
+		return
+	}
+	p.watching = true
+	go watchResize(p.Sizer, p.clock, p.resizeChan, p.contChan, p.stopChan)
+}
+
+// SetNotify watches for terminal resizes to change width.
+//
+// Deprecated: use WatchResize.
 func (p *Progress) SetNotify() {
-	signal.Notify(p.sigChan, syscall.SIGWINCH)
+	p.WatchResize()
 }
 
-func GetTerminalWidth() (int, error) {
-	var option string
-	switch runtime.GOOS {
-	case "darwin":
-		option = "-f"
-	case "linux":
-		option = "-F"
+// notifyResize sends on resize without blocking, a pending notification already covers this resize
+func notifyResize(resize chan<- struct{}) {
+	select {
+	case resize <- struct{}{}:
 	default:
-		option = "-f"
 	}
-	out, err := exec.Command(
-		"stty",
-		option,
-		"/dev/tty",
-		"size").Output()
+}
+
+// GetTerminalWidth returns the width available to the bars, which is the width of the terminal less the room for
+// decorators. Invalid terminal sizes are handled like the render loop handles them, see FallbackColumns and MinWidth.
+func GetTerminalWidth() (int, error) {
+	return terminalWidth(Sizer)
+}
+
+func terminalWidth(s TerminalSizer) (int, error) {
+	width, _, err := s.Size()
 	if err != nil {
-		return 0, ErrExecFail
+		return 0, err
 	}
-	idx := bytes.IndexByte(out, SizeToken)
-	width, _ := strconv.Atoi(string(out[idx+1 : len(out)-1]))
-	return int(width) - 20, nil
+	cols, _ := validSize(width, 0)
+	return barWidth(cols), nil
 }
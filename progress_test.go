@@ -0,0 +1,685 @@
+package uiprogress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// syncBuffer is a bytes.Buffer that is safe to read while the render loop is writing to it
+type syncBuffer struct {
+	buf bytes.Buffer
+	mtx sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Len() int {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Len()
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+func newTestProgress(out *syncBuffer) *Progress {
+	p := New()
+	p.Out = out
+	p.RefreshInterval = time.Millisecond
+	p.MaxRefreshInterval = p.RefreshInterval
+	p.AddBar(10)
+	return p
+}
+
+func TestPauseAll(t *testing.T) {
+	out1, out2 := &syncBuffer{}, &syncBuffer{}
+	p1, p2 := newTestProgress(out1), newTestProgress(out2)
+	p1.Start()
+	defer p1.Stop()
+	p2.Start()
+	defer p2.Stop()
+	waitFor(t, func() bool { return out1.Len() > 0 && out2.Len() > 0 })
+
+	PauseAll()
+	n1, n2 := out1.Len(), out2.Len()
+	// a change wakes the render loops, which mustn't draw while paused
+	p1.Bars[0].Incr()
+	p2.Bars[0].Incr()
+	p1.tick(nil)
+	p2.tick(nil)
+	if out1.Len() != n1 || out2.Len() != n2 {
+		t.Fatal("want", "no output while paused", "got", out1.Len()-n1, out2.Len()-n2)
+	}
+
+	ResumeAll()
+	waitFor(t, func() bool { return out1.Len() > n1 && out2.Len() > n2 })
+}
+
+func TestStopUnregisters(t *testing.T) {
+	p := newTestProgress(&syncBuffer{})
+	p.Start()
+	p.Stop()
+
+	activeMtx.Lock()
+	defer activeMtx.Unlock()
+	if _, ok := active[p]; ok {
+		t.Fatal("want", "stopped progress removed from registry")
+	}
+}
+
+func TestRenderPlain(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.plain = true
+	bar := p.Bars[0].AppendResults(true)
+	bar.IncrSuccess()
+
+	p.render()
+	p.render()
+	bar.Set(5)
+	p.render()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatal("want", 2, "lines", "got", len(lines), out.String())
+	}
+	if strings.Contains(out.String(), "\x1b") {
+		t.Fatalf("want no escape sequences, got %q", out.String())
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	for _, b := range []struct {
+		name    string
+		current int
+	}{{"a", 10}, {"b", 80}, {"c", 50}} {
+		name := b.name
+		p.AddBar(100).PrependFunc(func(*Bar) string { return name }).Set(b.current)
+	}
+	p.SortBy = SortByPercent
+	p.render()
+
+	got := out.String()
+	if !(strings.Index(got, "b [") < strings.Index(got, "c [") && strings.Index(got, "c [") < strings.Index(got, "a [")) {
+		t.Fatal("want", "b, c, a", "got", got)
+	}
+	for i, bar := range p.Bars {
+		if bar.ID() != i {
+			t.Fatal("want", i, "got", bar.ID())
+		}
+	}
+
+	out.buf.Reset()
+	p.SortBy = Reverse(SortByPercent)
+	p.render()
+	got = out.String()
+	if !(strings.Index(got, "a [") < strings.Index(got, "c [") && strings.Index(got, "c [") < strings.Index(got, "b [")) {
+		t.Fatal("want", "a, c, b", "got", got)
+	}
+}
+
+func TestRenderFunc(t *testing.T) {
+	p := New()
+	p.AddBar(10).PrependFunc(func(*Bar) string { return "first" })
+	p.AddBar(10).PrependFunc(func(*Bar) string { return "second" })
+
+	var frame []string
+	p.RenderFunc = func(lines []string) {
+		frame = append([]string{"header"}, lines...)
+		frame = append(frame, "footer")
+	}
+	p.render()
+
+	if len(frame) != 4 {
+		t.Fatal("want", 4, "got", len(frame))
+	}
+	for i, prefix := range []string{"header", "first [", "second [", "footer"} {
+		if !strings.HasPrefix(frame[i], prefix) {
+			t.Fatal("want", prefix, "got", frame[i])
+		}
+	}
+	if lines := p.Lines(); len(lines) != 2 || lines[1] != frame[2] {
+		t.Fatal("want", frame[1:3], "got", lines)
+	}
+}
+
+// fakeClock is a clock that advances only when slept on
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Sleep(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) Since(t time.Time) time.Duration { return c.now.Sub(t) }
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker { return fakeTicker{make(chan time.Time)} }
+
+// fakeTicker is a ticker that never ticks
+type fakeTicker struct {
+	c chan time.Time
+}
+
+func (t fakeTicker) C() <-chan time.Time { return t.c }
+
+func (fakeTicker) Stop() {}
+
+func TestRefreshBackoff(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.clock = clk
+	p.RefreshInterval = time.Millisecond * 10
+	p.MaxRefreshInterval = time.Millisecond * 200
+	frames := 0
+	p.RenderFunc = func([]string) { frames++ }
+	bar := p.AddBar(10)
+
+	// framesIn counts the frames rendered during d of simulated time
+	framesIn := func(d time.Duration) int {
+		frames = 0
+		for end := clk.now.Add(d); clk.now.Before(end); {
+			idle, _ := p.idleInterval()
+			<-clk.After(idle)
+			p.tick(nil)
+		}
+		return frames
+	}
+
+	if got := framesIn(time.Millisecond * 100); got != 10 {
+		t.Fatal("want", 10, "got", got)
+	}
+	framesIn(time.Second)
+	if got := framesIn(time.Second); got != 5 {
+		t.Fatal("want", 5, "got", got)
+	}
+
+	bar.Set(5)
+	framesIn(p.MaxRefreshInterval)
+	if got := p.refreshInterval(); got != p.RefreshInterval {
+		t.Fatal("want", p.RefreshInterval, "got", got)
+	}
+}
+
+func TestChangeWidth(t *testing.T) {
+	p := New()
+	p.Sizer = TerminalSizerFunc(func() (int, int, error) { return 100, 40, nil })
+	bar := p.AddBar(10)
+	p.ChangeWidth()
+	if bar.Width != 80 {
+		t.Fatal("want", 80, "got", bar.Width)
+	}
+}
+
+func TestRemoveBar(t *testing.T) {
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	bar1, bar2 := p.AddBar(10), p.AddBar(10)
+	p.render()
+
+	if !p.RemoveBar(bar1) || p.RemoveBar(bar1) {
+		t.Fatal("want", "bar removed once")
+	}
+	if len(p.Bars) != 1 || p.Bars[0] != bar2 {
+		t.Fatal("want", []*Bar{bar2}, "got", p.Bars)
+	}
+
+	bar2.Set(10)
+	p.AddBar(10)
+	if n := p.ClearCompleted(); n != 1 || len(p.Bars) != 1 || p.Bars[0] == bar2 {
+		t.Fatal("want", 1, "removed", "got", n, p.Bars)
+	}
+
+	p.RemoveBar(p.Bars[0])
+	out.buf.Reset()
+	p.render()
+	if out.Len() == 0 {
+		t.Fatal("want", "previous frame cleared")
+	}
+}
+
+func TestStartWithContext(t *testing.T) {
+	p := newTestProgress(&syncBuffer{})
+	ctx, cancel := context.WithCancel(context.Background())
+	p.StartWithContext(ctx)
+	cancel()
+
+	stopped := false
+	for i := 0; i < 100 && !stopped; i++ {
+		time.Sleep(time.Millisecond * 10)
+		p.mtx.RLock()
+		stopped = p.stopChan == nil
+		p.mtx.RUnlock()
+	}
+	if !stopped {
+		t.Fatal("want", "progress stopped when the context is cancelled")
+	}
+	p.Stop()
+}
+
+func TestSetSortCompletedLast(t *testing.T) {
+	p := New()
+	done1, running, done2 := p.AddBar(1), p.AddBar(2), p.AddBar(1)
+	done1.Incr()
+	done2.Incr()
+
+	p.SetSort(SortCompletedLast)
+	want := []*Bar{running, done1, done2}
+	for i, bar := range p.sortedBars() {
+		if bar != want[i] {
+			t.Fatal("want", want[i].ID(), "at", i, "got", bar.ID())
+		}
+	}
+
+	p.SetSort(Reverse(SortCompletedLast))
+	want = []*Bar{done1, done2, running}
+	for i, bar := range p.sortedBars() {
+		if bar != want[i] {
+			t.Fatal("want", want[i].ID(), "at", i, "got", bar.ID())
+		}
+	}
+}
+
+func TestStartPlainWhenNotTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	p := newTestProgress(&syncBuffer{})
+	p.Out = w
+	p.Start()
+	defer p.Stop()
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if !p.plain {
+		t.Fatal("want", "plain rendering when the output is not a terminal")
+	}
+}
+
+func TestRenderPlainSteps(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.plain = true
+	p.PlainStep = 25
+	bar := p.AddBar(100)
+	p.RemoveBar(p.Bars[0])
+
+	for i := 0; i <= 100; i++ {
+		bar.Set(i)
+		p.render()
+	}
+	if got := strings.Count(out.String(), "\n"); got != 5 {
+		t.Fatal("want", 5, "lines", "got", got, out.String())
+	}
+}
+
+func TestPauseClearsBars(t *testing.T) {
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	p.AddBar(10)
+	p.render()
+
+	out.buf.Reset()
+	p.Pause()
+	if !strings.Contains(out.String(), "\x1b[") {
+		t.Fatalf("want bars cleared on pause, got %q", out.String())
+	}
+
+	out.buf.Reset()
+	p.Resume()
+	if !strings.Contains(out.String(), "[") {
+		t.Fatalf("want bars redrawn on resume, got %q", out.String())
+	}
+}
+
+func TestBypass(t *testing.T) {
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	p.AddBar(10)
+	p.render()
+
+	out.buf.Reset()
+	fmt.Fprintln(p.Bypass(), "log line")
+	got := out.String()
+	if i := strings.Index(got, "log line\n"); i < 0 || !strings.Contains(got[i:], "[") {
+		t.Fatalf("want log line followed by the bars, got %q", got)
+	}
+}
+
+func TestRenderOnChange(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.MaxRefreshInterval = -1
+	bar := p.Bars[0]
+	p.Start()
+	defer p.Stop()
+	waitFor(t, func() bool { return out.Len() > 0 })
+
+	// frames are written with the lock held, the render loop waits for a change without a timeout while idle
+	p.mtx.RLock()
+	n := out.Len()
+	_, timeout := p.idleInterval()
+	p.mtx.RUnlock()
+	if timeout {
+		t.Fatal("want", "no redraws while idle")
+	}
+	bar.Incr()
+	waitFor(t, func() bool { return out.Len() > n })
+}
+
+func TestChangeWidthAuto(t *testing.T) {
+	p := New()
+	p.Width = WidthAuto
+	cols := 100
+	p.Sizer = TerminalSizerFunc(func() (int, int, error) { return cols, 40, nil })
+	bar := p.AddBar(10).AppendCompleted()
+	if got := len(p.Lines()[0]); got != 100 {
+		t.Fatal("want", 100, "got", got)
+	}
+
+	cols = 60
+	p.ChangeWidth()
+	if got := len(p.Lines()[0]); got != 60 || bar.Width != WidthAuto {
+		t.Fatal("want", 60, "got", got, bar.Width)
+	}
+}
+
+func TestSetMaxVisibleBars(t *testing.T) {
+	p := New()
+	done := p.AddBar(10)
+	done.Set(10)
+	running := p.AddBar(10)
+	running.Set(5)
+	idle := p.AddBar(10)
+	p.AddBar(10).Set(5)
+
+	p.SetMaxVisibleBars(2)
+	lines := p.Lines()
+	if len(lines) != 3 {
+		t.Fatal("want", 3, "lines", "got", len(lines), lines)
+	}
+	if want := running.String(); lines[0] != want {
+		t.Fatal("want", want, "got", lines[0])
+	}
+	if want := "… and 2 more (50% overall)"; lines[2] != want {
+		t.Fatal("want", want, "got", lines[2])
+	}
+	for _, line := range lines[:2] {
+		if line == idle.String() || line == done.String() {
+			t.Fatal("want", "idle and completed bars hidden", "got", lines)
+		}
+	}
+}
+
+func TestStopWithSummary(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.lw.setOut(out)
+	p.Bars[0].SetName("download").Set(10)
+	p.Start()
+	p.StopWithSummary()
+
+	if got := out.String(); !strings.Contains(got, "[") || !strings.HasSuffix(got, "download: 10/10 (100%) in ---\n") {
+		t.Fatalf("want final frame then summary, got %q", got)
+	}
+}
+
+func TestSlowOutputThrottle(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.clock = clk
+	p.SetMaxFPS(100)
+	p.MaxRefreshInterval = time.Second
+	p.RenderFunc = func([]string) { clk.Sleep(time.Millisecond * 50) }
+	p.AddBar(10)
+
+	if got := p.frameInterval(); got != time.Millisecond*10 {
+		t.Fatal("want", time.Millisecond*10, "got", got)
+	}
+	for i := 0; i < 10; i++ {
+		p.tick(nil)
+	}
+	if got := p.frameInterval(); got < time.Millisecond*150 || got > time.Millisecond*200 {
+		t.Fatal("want", "interval backed off to about 4 frame writes", "got", got)
+	}
+}
+
+func TestAddNamedBar(t *testing.T) {
+	p := New()
+	p.AddBar(10)
+	download := p.AddNamedBar("download", 10)
+	if got := p.Bar("download"); got != download || got.Name() != "download" {
+		t.Fatal("want", download, "got", got)
+	}
+	if got := p.Bar("missing"); got != nil {
+		t.Fatal("want", nil, "got", got)
+	}
+}
+
+func TestLog(t *testing.T) {
+	var log bytes.Buffer
+	p := New()
+	p.clock = &fakeClock{now: time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC)}
+	p.RenderFunc = func([]string) {}
+	p.Log = &log
+	p.LogStep = 50
+	bar := p.AddBar(100)
+
+	for i := 0; i <= 100; i += 10 {
+		bar.Set(i)
+		p.tick(nil)
+	}
+	lines := strings.Split(strings.TrimSuffix(log.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatal("want", 3, "lines", "got", len(lines), log.String())
+	}
+	if !strings.HasPrefix(lines[0], "2016-01-02T15:04:") || !strings.HasSuffix(lines[2], "]") {
+		t.Fatal("want", "timestamped plain lines", "got", lines)
+	}
+}
+
+func TestOnCompleteStyle(t *testing.T) {
+	p := New()
+	cleared := p.AddBar(1).SetOnCompleteStyle(CompleteClear, "")
+	replaced := p.AddBar(1, WithOnCompleteStyle(CompleteReplace, "✔ fetched"))
+	left := p.AddBar(1)
+	if got := len(p.Lines()); got != 3 {
+		t.Fatal("want", 3, "got", got)
+	}
+
+	cleared.Incr()
+	replaced.Incr()
+	left.Incr()
+	lines := p.Lines()
+	if len(lines) != 2 || lines[0] != "✔ fetched" || lines[1] != left.String() {
+		t.Fatal("want", []string{"✔ fetched", left.String()}, "got", lines)
+	}
+}
+
+func TestResizeRedraw(t *testing.T) {
+	out := &syncBuffer{}
+	p := New()
+	p.lw.setOut(out)
+	p.Sizer = TerminalSizerFunc(func() (int, int, error) { return 30, 20, nil })
+	p.AddBar(10).PrependFunc(func(*Bar) string { return strings.Repeat("x", 40) })
+	p.render()
+
+	out.buf.Reset()
+	p.ChangeWidth()
+	p.redraw()
+	got := out.String()
+	if !strings.Contains(got, "\x1b[") || !strings.Contains(got, "xxx") {
+		t.Fatalf("want previous frame cleared and bars redrawn, got %q", got)
+	}
+	if line := p.Lines()[0]; strutil.Width(line) != 30 {
+		t.Fatal("want", "line truncated to 30 cells", "got", line)
+	}
+}
+
+func TestStartError(t *testing.T) {
+	f, err := ioutil.TempFile("", "uiprogress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Close()
+
+	p, err := New().WithOutput(f).Start()
+	if err == nil {
+		p.Stop()
+		t.Fatal("want", "error starting with a closed output")
+	}
+	if _, err := New().WithOutput(nil).Start(); err != ErrNoOutput {
+		t.Fatal("want", ErrNoOutput, "got", err)
+	}
+
+	p, err = New().WithOutput(&syncBuffer{}).Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Stop()
+}
+
+func TestSetOutput(t *testing.T) {
+	first, second := &syncBuffer{}, &syncBuffer{}
+	p := New().WithOutput(first)
+	p.RefreshInterval = time.Millisecond
+	bar := p.AddBar(10)
+	if _, err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+	waitFor(t, func() bool { return first.Len() > 0 })
+
+	if err := p.SetOutput(nil); err != ErrNoOutput {
+		t.Fatal("want", ErrNoOutput, "got", err)
+	}
+	if err := p.SetOutput(second); err != nil {
+		t.Fatal(err)
+	}
+	bar.Incr()
+	waitFor(t, func() bool { return second.Len() > 0 })
+	if !strings.HasSuffix(first.String(), "\x1b[1A\r\x1b[J") || strings.HasPrefix(second.String(), "\x1b[1A") {
+		t.Fatalf("want the bars moved to the new output, got %q and %q", first.String(), second.String())
+	}
+}
+
+// waitFor waits up to a second for cond to hold
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("want", "condition", "got", "timeout")
+		}
+	}
+}
+
+func TestRestart(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.Stop()
+	p.Stop()
+
+	for i := 0; i < 3; i++ {
+		if _, err := p.Start(); err != nil {
+			t.Fatal(err)
+		}
+		p.Start()
+		time.Sleep(time.Millisecond * 10)
+		p.Stop()
+		p.Stop()
+	}
+
+	time.Sleep(time.Millisecond * 10)
+	n := out.Len()
+	if n == 0 {
+		t.Fatal("want", "output while started")
+	}
+	time.Sleep(time.Millisecond * 20)
+	if out.Len() != n {
+		t.Fatal("want", "no output once stopped", "got", out.Len()-n)
+	}
+}
+
+func TestDefaultSettings(t *testing.T) {
+	p := Default()
+	out, width, interval := p.Out, p.Width, p.RefreshInterval
+	defer func() {
+		p.Out, p.Width, p.RefreshInterval = out, width, interval
+	}()
+
+	var buf bytes.Buffer
+	if err := SetOutput(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if p.Out != &buf {
+		t.Fatal("want output set on the default progress")
+	}
+	SetRefreshInterval(time.Second)
+	if p.RefreshInterval != time.Second {
+		t.Fatal("want", time.Second, "got", p.RefreshInterval)
+	}
+
+	bar := p.AddBar(10)
+	defer p.RemoveBar(bar)
+	SetWidth(30)
+	if bar.Width != 30 || p.Width != 30 {
+		t.Fatal("want", 30, "got", bar.Width, p.Width)
+	}
+}
+
+func TestStopRendersFinalFrame(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.RefreshInterval = time.Hour
+	p.MaxRefreshInterval = time.Hour
+	p.Start()
+	p.Bars[0].Set(10)
+
+	stopped := make(chan struct{})
+	go func() {
+		p.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("want", "Stop to return without waiting out the refresh interval", "got", "timeout")
+	}
+	if want := "[" + strings.Repeat("=", 68) + "]"; !strings.Contains(out.String(), want) {
+		t.Fatalf("want final frame %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,144 @@
+// Package progresstest provides utilities for testing what a uiprogress container renders
+package progresstest
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// clearLine is the sequence uilive writes to erase a line of the previous frame and move the cursor up to it
+const clearLine = "\x1b[1A\x1b[2K"
+
+var (
+	// clearFrame matches the sequence the ANSI writer writes to erase the lines of the previous frame at once
+	clearFrame = regexp.MustCompile(`^\x1b\[(\d+)A\r\x1b\[J`)
+	// patchLine matches the rewrite of a line of the previous frame, moving the cursor up or down to it
+	patchLine = regexp.MustCompile(`^\x1b\[(\d+)([AB])\r(.*?)\x1b\[K`)
+	// patchEnd matches the move back below the frame after its lines were rewritten
+	patchEnd = regexp.MustCompile(`^\x1b\[(\d+)B\r$`)
+)
+
+// Frame is a single write to the output of a progress container, a redraw of the bars or a line written through
+// Bypass
+type Frame struct {
+	// Cleared is the number of lines of the previous frames erased before the frame was written
+	Cleared int
+
+	// Lines are the lines of the frame, with the ANSI colors they were rendered with
+	Lines []string
+
+	// Patched is the number of lines rewritten in place when the frame was drawn by patching the previous one,
+	// in which case Lines is the whole frame as displayed
+	Patched int
+}
+
+// String returns the lines of the frame joined by newlines
+func (f Frame) String() string {
+	return strings.Join(f.Lines, "\n")
+}
+
+// Recorder is an io.Writer recording the frames written by a progress container, for golden tests of the rendered
+// output. Set it as the Out of the container.
+type Recorder struct {
+	mtx     sync.Mutex
+	frames  []Frame
+	cleared int
+}
+
+// NewRecorder returns a new recorder with no frames
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Write records p as a frame. Line erasing sequences are counted into the next frame instead.
+func (r *Recorder) Write(p []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	s := string(p)
+	for strings.HasPrefix(s, clearLine) {
+		r.cleared++
+		s = s[len(clearLine):]
+	}
+	if m := clearFrame.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		r.cleared += n
+		s = s[len(m[0]):]
+	}
+	if s == "" {
+		return len(p), nil
+	}
+	if f, ok := r.patch(s); ok {
+		r.frames = append(r.frames, f)
+		return len(p), nil
+	}
+	r.frames = append(r.frames, Frame{Cleared: r.cleared, Lines: strings.Split(strings.TrimSuffix(s, "\n"), "\n")})
+	r.cleared = 0
+	return len(p), nil
+}
+
+// patch applies s to the last frame when it rewrites lines of it in place, returning the frame displayed
+func (r *Recorder) patch(s string) (Frame, bool) {
+	if len(r.frames) == 0 || r.cleared > 0 {
+		return Frame{}, false
+	}
+	f := r.frames[len(r.frames)-1]
+	lines := append([]string(nil), f.Lines...)
+	row, patched := len(lines), 0
+	for {
+		m := patchLine.FindStringSubmatch(s)
+		if m == nil {
+			break
+		}
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "A" {
+			n = -n
+		}
+		if row += n; row < 0 || row >= len(lines) {
+			return Frame{}, false
+		}
+		lines[row] = m[3]
+		patched++
+		s = s[len(m[0]):]
+	}
+	if patched == 0 || !patchEnd.MatchString(s) {
+		return Frame{}, false
+	}
+	return Frame{Lines: lines, Patched: patched}, true
+}
+
+// Frames returns the frames recorded so far
+func (r *Recorder) Frames() []Frame {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]Frame(nil), r.frames...)
+}
+
+// Last returns the last frame recorded, the zero frame when there is none
+func (r *Recorder) Last() Frame {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.frames) == 0 {
+		return Frame{}
+	}
+	return r.frames[len(r.frames)-1]
+}
+
+// String returns the frames recorded so far, each followed by a blank line, in a form suited for golden files
+func (r *Recorder) String() string {
+	var buf bytes.Buffer
+	for _, f := range r.Frames() {
+		buf.WriteString(f.String())
+		buf.WriteString("\n\n")
+	}
+	return buf.String()
+}
+
+// Reset discards the frames recorded so far
+func (r *Recorder) Reset() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.frames, r.cleared = nil, 0
+}
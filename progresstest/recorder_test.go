@@ -0,0 +1,63 @@
+package progresstest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+)
+
+func TestRecorderWrite(t *testing.T) {
+	r := NewRecorder()
+	r.Write([]byte("a\nb\n"))
+	r.Write([]byte(clearLine + clearLine))
+	r.Write([]byte("c\nd\n"))
+
+	frames := r.Frames()
+	if len(frames) != 2 {
+		t.Fatal("want", 2, "got", len(frames))
+	}
+	if f := frames[1]; f.Cleared != 2 || f.String() != "c\nd" {
+		t.Fatal("want", "c and d after clearing 2 lines", "got", f)
+	}
+	if got := r.String(); got != "a\nb\n\nc\nd\n\n" {
+		t.Fatalf("want %q, got %q", "a\nb\n\nc\nd\n\n", got)
+	}
+
+	r.Write([]byte("\x1b[2A\r\x1b[Je\n"))
+	if f := r.Last(); f.Cleared != 2 || f.String() != "e" {
+		t.Fatal("want", "e after clearing 2 lines at once", "got", f)
+	}
+}
+
+func TestRecorderPatch(t *testing.T) {
+	r := NewRecorder()
+	r.Write([]byte("a\nb\nc\n"))
+	r.Write([]byte("\x1b[3A\rA\x1b[K\x1b[2B\rC\x1b[K\x1b[1B\r"))
+
+	if f := r.Last(); f.Patched != 2 || f.String() != "A\nb\nC" {
+		t.Fatal("want", "A, b and C with 2 lines patched", "got", f)
+	}
+}
+
+func TestRecorderProgress(t *testing.T) {
+	r := NewRecorder()
+	p := uiprogress.New().WithOutput(r)
+	p.Width = 10
+	bar := p.AddBar(10)
+	if _, err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+	bar.Set(10)
+
+	for deadline := time.Now().Add(time.Second); r.Last().String() != "[========]"; {
+		if time.Now().After(deadline) {
+			t.Fatal("want", "[========]", "got", r.Last())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if f := r.Last(); len(r.Frames()) > 1 && f.Cleared != 1 && f.Patched != 1 {
+		t.Fatal("want", 1, "got", f.Cleared)
+	}
+}
@@ -0,0 +1,50 @@
+// Package promprogress exports the bars of a uiprogress container as Prometheus metrics
+package promprogress
+
+import (
+	"strconv"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector exporting the current value, total and rate of each bar of a progress
+// container as gauges, labeled with the id and name of the bar
+type Collector struct {
+	p *uiprogress.Progress
+
+	current, total, rate *prometheus.Desc
+}
+
+// NewCollector returns a collector for the bars of p, with metric names prefixed by namespace when it isn't empty
+func NewCollector(p *uiprogress.Progress, namespace string) *Collector {
+	labels := []string{"id", "name"}
+	return &Collector{
+		p:       p,
+		current: prometheus.NewDesc(prometheus.BuildFQName(namespace, "progress", "current"), "Current value of the bar.", labels, nil),
+		total:   prometheus.NewDesc(prometheus.BuildFQName(namespace, "progress", "total"), "Total value of the bar, 0 when indeterminate.", labels, nil),
+		rate:    prometheus.NewDesc(prometheus.BuildFQName(namespace, "progress", "rate"), "Smoothed rate of progress of the bar in units per second.", labels, nil),
+	}
+}
+
+// Register registers a collector for the bars of p with r
+func Register(r prometheus.Registerer, p *uiprogress.Progress, namespace string) error {
+	return r.Register(NewCollector(p, namespace))
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.current
+	ch <- c.total
+	ch <- c.rate
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, s := range c.p.Snapshot() {
+		id := strconv.Itoa(s.ID)
+		ch <- prometheus.MustNewConstMetric(c.current, prometheus.GaugeValue, float64(s.Current), id, s.Name)
+		ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.Total), id, s.Name)
+		ch <- prometheus.MustNewConstMetric(c.rate, prometheus.GaugeValue, s.Rate, id, s.Name)
+	}
+}
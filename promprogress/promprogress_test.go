@@ -0,0 +1,33 @@
+package promprogress
+
+import (
+	"testing"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	p := uiprogress.New()
+	p.AddNamedBar("download", 10).Set(4)
+	r := prometheus.NewRegistry()
+	if err := Register(r, p, "job"); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := r.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]float64)
+	for _, f := range families {
+		m := f.GetMetric()[0]
+		values[f.GetName()] = m.GetGauge().GetValue()
+		if labels := m.GetLabel(); labels[0].GetValue() != "0" || labels[1].GetValue() != "download" {
+			t.Fatal("want", "id 0 and name download", "got", labels)
+		}
+	}
+	if values["job_progress_current"] != 4 || values["job_progress_total"] != 10 {
+		t.Fatal("want", "current 4 and total 10", "got", values)
+	}
+}
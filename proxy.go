@@ -0,0 +1,71 @@
+package uiprogress
+
+import "io"
+
+// proxyReader is an io.Reader that increments the bar by the number of bytes read
+type proxyReader struct {
+	r   io.Reader
+	bar *Bar
+}
+
+func (p *proxyReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		if ierr := p.bar.incr(int64(n)); ierr != nil && p.bar.overflowPolicy() == OverflowError && err == nil {
+			err = ierr
+		}
+	}
+	return n, err
+}
+
+// proxyWriter is an io.Writer that increments the bar by the number of bytes written
+type proxyWriter struct {
+	w   io.Writer
+	bar *Bar
+}
+
+func (p *proxyWriter) Write(buf []byte) (int, error) {
+	n, err := p.w.Write(buf)
+	if n > 0 {
+		if ierr := p.bar.incr(int64(n)); ierr != nil && p.bar.overflowPolicy() == OverflowError && err == nil {
+			err = ierr
+		}
+	}
+	return n, err
+}
+
+// NewProxyReader returns a reader that reads from r and increments the bar by the number of bytes read.
+// The current value is clamped at the total.
+func (b *Bar) NewProxyReader(r io.Reader) io.Reader {
+	return &proxyReader{r: r, bar: b}
+}
+
+// NewProxyWriter returns a writer that writes to w and increments the bar by the number of bytes written.
+// The current value is clamped at the total.
+func (b *Bar) NewProxyWriter(w io.Writer) io.Writer {
+	return &proxyWriter{w: w, bar: b}
+}
+
+// Copy copies from src to dst like io.Copy, with a bar of the default progress container counting the bytes copied.
+// A total of 0 or less makes the bar indeterminate until the copy is done. The bar completes when the copy succeeds
+// and fails with the error otherwise.
+func Copy(dst io.Writer, src io.Reader, total int64, opts ...BarOption) (int64, error) {
+	return defaultProgress.Copy(dst, src, total, opts...)
+}
+
+// Copy copies from src to dst like io.Copy, with a bar of the container counting the bytes copied, see Copy
+func (p *Progress) Copy(dst io.Writer, src io.Reader, total int64, opts ...BarOption) (int64, error) {
+	p.mtx.Lock()
+	bar := p.newBar(total, opts)
+	p.addBar(bar)
+	p.mtx.Unlock()
+
+	n, err := io.Copy(dst, bar.NewProxyReader(src))
+	if err != nil {
+		bar.SetError(err)
+		return n, err
+	}
+	// the total was only an estimate, the bar completes with the bytes actually copied
+	bar.Finish64(n)
+	return n, nil
+}
@@ -0,0 +1,57 @@
+package uiprogress
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestProxyReader(t *testing.T) {
+	src := strings.Repeat("x", 1000)
+	b := NewBar(len(src))
+	n, err := io.Copy(ioutil.Discard, b.NewProxyReader(strings.NewReader(src)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int(n) != b.Current() {
+		t.Fatal("want", n, "got", b.Current())
+	}
+}
+
+func TestProxyWriter(t *testing.T) {
+	b := NewBar(10)
+	var buf bytes.Buffer
+	io.Copy(b.NewProxyWriter(&buf), strings.NewReader(strings.Repeat("x", 25)))
+	if buf.Len() != 25 {
+		t.Fatal("want", 25, "got", buf.Len())
+	}
+	if b.Current() != 10 {
+		t.Fatal("want", 10, "got", b.Current())
+	}
+}
+
+func TestCopy(t *testing.T) {
+	p := New()
+	var dst bytes.Buffer
+	n, err := p.Copy(&dst, strings.NewReader(strings.Repeat("x", 150)), 100, WithName("copy"))
+	if err != nil || n != 150 || dst.Len() != 150 {
+		t.Fatal("want", 150, "got", n, err)
+	}
+	bar := p.Bar("copy")
+	if bar == nil || !bar.Completed() || bar.Current() != 150 {
+		t.Fatal("want", "completed bar of 150", "got", bar)
+	}
+
+	_, err = p.Copy(&dst, io.MultiReader(strings.NewReader("x"), iotest.ErrReader(io.ErrUnexpectedEOF)), 0)
+	if err != io.ErrUnexpectedEOF || p.Bars[1].Err() != err {
+		t.Fatal("want", io.ErrUnexpectedEOF, "got", err, p.Bars[1].Err())
+	}
+
+	n, err = p.Copy(&dst, strings.NewReader(""), 0, WithName("empty"))
+	if bar := p.Bar("empty"); err != nil || n != 0 || !bar.Completed() || bar.CompletedPercent() != 100 {
+		t.Fatal("want", "empty copy completed", "got", n, err, bar.Completed())
+	}
+}
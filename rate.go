@@ -0,0 +1,149 @@
+package uiprogress
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// RateSmoothing is the weight of the latest sample in the exponentially weighted moving average of the rate.
+// Values closer to 1 follow changes in rate faster, values closer to 0 give a steadier ETA.
+var RateSmoothing = 0.3
+
+// SIBytes makes AppendBytes and PrependBytes format byte counts in SI units (kB, MB) instead of binary units (KiB, MiB)
+var SIBytes = false
+
+// rateSampleInterval is the minimum time over which progress is counted into a single rate sample
+const rateSampleInterval = time.Millisecond * 100
+
+// updateRate counts delta units of progress made at now into the rate. The caller must hold the lock.
+func (b *Bar) updateRate(now time.Time, delta int64) {
+	if b.rateSampled.IsZero() {
+		// progress reported by the first update was made over an unknown time
+		b.rateSampled = now
+		return
+	}
+	if delta > 0 {
+		b.rateUnits += delta
+	}
+	dt := now.Sub(b.rateSampled)
+	if dt < rateSampleInterval {
+		return
+	}
+	sample := float64(b.rateUnits) / dt.Seconds()
+	b.recordRate(sample)
+	if b.rate == 0 {
+		b.rate = sample
+	} else {
+		b.rate = RateSmoothing*sample + (1-RateSmoothing)*b.rate
+	}
+	b.rateUnits = 0
+	b.rateSampled = now
+}
+
+// sampleIdle counts a sample without progress into the rate when the bar received no update for rateSampleInterval
+// at now, so that the rate of a stalled transfer drops. It must be called without the lock held.
+func (b *Bar) sampleIdle(now time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.rateSampled.IsZero() || b.completed() || b.err != nil || now.Sub(b.rateSampled) < rateSampleInterval {
+		return
+	}
+	rate := b.rate
+	b.updateRate(now, 0)
+	if b.rate != rate {
+		b.markDirty()
+	}
+}
+
+// sampleRates counts the samples without progress of the idle bars of the container into their rate
+func (p *Progress) sampleRates() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	now := p.clock.Now()
+	p.mtx.RUnlock()
+	for _, bar := range bars {
+		bar.sampleIdle(now)
+	}
+}
+
+// Rate returns the smoothed rate of progress in units per second. Until enough progress is sampled,
+// it is the average rate between the first and the last update.
+func (b *Bar) Rate() float64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if b.rate > 0 {
+		return b.rate
+	}
+	if b.timeElapsed <= 0 {
+		return 0
+	}
+	return float64(b.current-b.startCurrent) / b.timeElapsed.Seconds()
+}
+
+// ETA returns the estimated time remaining until the bar completes, based on the smoothed rate. It returns 0 when
+// the rate is unknown or the bar is complete or failed.
+func (b *Bar) ETA() time.Duration {
+	if b.Err() != nil {
+		return 0
+	}
+	rate := b.Rate()
+	remaining := b.Total64() - b.Current64()
+	if rate <= 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second))
+}
+
+// ETAString returns the formatted string representation of the estimated time remaining
+func (b *Bar) ETAString() string {
+	return strutil.PrettyTime(b.ETA())
+}
+
+// RateString returns the formatted rate of units per second, for example "12.5/s"
+func (b *Bar) RateString() string {
+	return fmt.Sprintf("%.1f/s", b.Rate())
+}
+
+// BytesRateString returns the formatted rate of bytes per second in binary units, for example "1.5 MiB/s"
+func (b *Bar) BytesRateString() string {
+	return strutil.FormatBytes(int64(b.Rate()), false) + "/s"
+}
+
+// BytesString returns the bytes done, the total and the rate, for example "154.3 MiB / 1.2 GiB (12.5 MiB/s)",
+// in SI units when si is set
+func (b *Bar) BytesString(si bool) string {
+	return fmt.Sprintf("%s / %s (%s/s)", strutil.FormatBytes(b.Current64(), si), strutil.FormatBytes(b.Total64(), si),
+		strutil.FormatBytes(int64(b.Rate()), si))
+}
+
+// AppendETA appends the estimated time remaining to the progress bar
+func (b *Bar) AppendETA() *Bar {
+	return b.AppendDecorator(ETA())
+}
+
+// PrependETA prepends the estimated time remaining to the progress bar
+func (b *Bar) PrependETA() *Bar {
+	return b.PrependDecorator(ETA())
+}
+
+// AppendRate appends the rate of units per second to the progress bar
+func (b *Bar) AppendRate() *Bar {
+	return b.AppendDecorator(Rate())
+}
+
+// AppendBytesRate appends the rate of bytes per second to the progress bar
+func (b *Bar) AppendBytesRate() *Bar {
+	return b.AppendDecorator(BytesRate())
+}
+
+// AppendBytes appends the bytes done, the total and the rate to the progress bar, in SI units when SIBytes is set
+func (b *Bar) AppendBytes() *Bar {
+	return b.AppendDecorator(Bytes(SIBytes))
+}
+
+// PrependBytes prepends the bytes done, the total and the rate to the progress bar, in SI units when SIBytes is set
+func (b *Bar) PrependBytes() *Bar {
+	return b.PrependDecorator(Bytes(SIBytes))
+}
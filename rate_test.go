@@ -0,0 +1,41 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateSmoothing(t *testing.T) {
+	b := NewBar(1000)
+	now := time.Unix(0, 0)
+	b.updateRate(now, 0)
+
+	// 100 units per second for a second
+	for i := 0; i < 10; i++ {
+		now = now.Add(rateSampleInterval)
+		b.updateRate(now, 10)
+	}
+	if b.rate != 100 {
+		t.Fatal("want", 100, "got", b.rate)
+	}
+
+	// a single burst moves the rate towards it without jumping
+	now = now.Add(rateSampleInterval)
+	b.updateRate(now, 100)
+	if b.rate <= 100 || b.rate >= 1000 {
+		t.Fatal("want", "rate between 100 and 1000", "got", b.rate)
+	}
+}
+
+func TestETA(t *testing.T) {
+	b := NewBar(1000)
+	b.current = 500
+	b.rate = 50
+	if got := b.ETA(); got != time.Second*10 {
+		t.Fatal("want", time.Second*10, "got", got)
+	}
+	b.current = 1000
+	if got := b.ETA(); got != 0 {
+		t.Fatal("want", 0, "got", got)
+	}
+}
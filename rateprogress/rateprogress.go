@@ -0,0 +1,75 @@
+// Package rateprogress shows the throughput allowed by golang.org/x/time/rate limiters on uiprogress bars
+package rateprogress
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+	"github.com/gosuri/uiprogress/util/strutil"
+	"golang.org/x/time/rate"
+)
+
+// Limiter is a rate.Limiter advancing a bar by the tokens it hands out, one token for each unit of progress
+type Limiter struct {
+	*rate.Limiter
+
+	// Bar is advanced by the tokens consumed
+	Bar *uiprogress.Bar
+}
+
+// Wrap returns a Limiter advancing bar as the tokens of l are consumed
+func Wrap(l *rate.Limiter, bar *uiprogress.Bar) *Limiter {
+	return &Limiter{Limiter: l, Bar: bar}
+}
+
+// Wait blocks until a token is available and advances the bar by one
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens are available and advances the bar by n. The bar is not advanced when it fails.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := l.Limiter.WaitN(ctx, n); err != nil {
+		return err
+	}
+	l.Bar.IncrBy(n)
+	return nil
+}
+
+// Allow reports whether a token is available now, consuming it and advancing the bar by one when it is
+func (l *Limiter) Allow() bool {
+	return l.AllowN(time.Now(), 1)
+}
+
+// AllowN reports whether n tokens are available at t, consuming them and advancing the bar by n when they are
+func (l *Limiter) AllowN(t time.Time, n int) bool {
+	if !l.Limiter.AllowN(t, n) {
+		return false
+	}
+	l.Bar.IncrBy(n)
+	return true
+}
+
+// Decorator returns a decorator rendering the actual rate of the bar next to the limit of l in binary byte units,
+// for example "2.1 MiB/s (cap 5.0 MiB/s)"
+func Decorator(l *rate.Limiter) uiprogress.Decorator {
+	return uiprogress.DecoratorFunc(func(b *uiprogress.Bar) string {
+		return fmt.Sprintf("%s (cap %s)", b.BytesRateString(), limitString(l.Limit()))
+	})
+}
+
+// Decorator returns a decorator rendering the actual rate of the bar next to the limit, see Decorator
+func (l *Limiter) Decorator() uiprogress.Decorator {
+	return Decorator(l.Limiter)
+}
+
+// limitString formats a limit in bytes per second
+func limitString(limit rate.Limit) string {
+	if limit == rate.Inf || float64(limit) > math.MaxInt64 {
+		return "unlimited"
+	}
+	return strutil.FormatBytes(int64(limit), false) + "/s"
+}
@@ -0,0 +1,47 @@
+package rateprogress
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gosuri/uiprogress"
+	"golang.org/x/time/rate"
+)
+
+func TestLimiter(t *testing.T) {
+	bar := uiprogress.NewBar(100)
+	l := Wrap(rate.NewLimiter(rate.Inf, 10), bar)
+
+	if err := l.WaitN(context.Background(), 40); err != nil {
+		t.Fatal(err)
+	}
+	if !l.Allow() {
+		t.Fatal("want token allowed")
+	}
+	if got := bar.Current(); got != 41 {
+		t.Fatal("want", 41, "got", got)
+	}
+
+	l = Wrap(rate.NewLimiter(1, 1), bar)
+	now := time.Now()
+	l.AllowN(now, 1)
+	if l.AllowN(now, 1) {
+		t.Fatal("want token denied")
+	}
+	if got := bar.Current(); got != 42 {
+		t.Fatal("want bar advanced by allowed tokens only, got", got)
+	}
+}
+
+func TestDecorator(t *testing.T) {
+	bar := uiprogress.NewBar(100)
+	got := Decorator(rate.NewLimiter(5*1024*1024, 1024)).Decor(bar)
+	if !strings.HasSuffix(got, " (cap 5.0 MiB/s)") {
+		t.Fatal("want", "0 B/s (cap 5.0 MiB/s)", "got", got)
+	}
+	if got := Wrap(rate.NewLimiter(rate.Inf, 0), bar).Decorator().Decor(bar); !strings.HasSuffix(got, "(cap unlimited)") {
+		t.Fatal("want", "(cap unlimited)", "got", got)
+	}
+}
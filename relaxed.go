@@ -0,0 +1,40 @@
+package uiprogress
+
+import "sync/atomic"
+
+// SetRelaxed sets the current count of the bar without taking its lock, for producers updating it millions of times
+// per second. The count is applied when the progress container renders its next frame, or on Reconcile, with the
+// overflow policy of a Set, and Current returns it only then.
+func (b *Bar) SetRelaxed(n int) {
+	b.SetRelaxed64(int64(n))
+}
+
+// SetRelaxed64 is like SetRelaxed but takes an int64 count
+func (b *Bar) SetRelaxed64(n int64) {
+	atomic.StoreInt64(&b.relaxed, n)
+	if atomic.SwapInt32(&b.relaxedPending, 1) == 0 {
+		// wake up the render loop once per frame rather than on each update
+		select {
+		case b.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Reconcile applies the count last set with SetRelaxed to the bar
+func (b *Bar) Reconcile() error {
+	if atomic.SwapInt32(&b.relaxedPending, 0) == 0 {
+		return nil
+	}
+	return b.Set64(atomic.LoadInt64(&b.relaxed))
+}
+
+// reconcile applies the counts set with SetRelaxed to the bars of the container
+func (p *Progress) reconcile() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	p.mtx.RUnlock()
+	for _, bar := range bars {
+		bar.Reconcile()
+	}
+}
@@ -0,0 +1,41 @@
+package uiprogress
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSetRelaxed(t *testing.T) {
+	p := New()
+	p.RefreshInterval = 0
+	p.RenderFunc = func([]string) {}
+	bar := p.AddBar(1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; n <= 1000; n++ {
+				bar.SetRelaxed(n)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := bar.Current(); got != 0 {
+		t.Fatal("want", 0, "got", got)
+	}
+	p.tick(nil)
+	if got := bar.Current(); got != 1000 {
+		t.Fatal("want", 1000, "got", got)
+	}
+}
+
+func BenchmarkSetRelaxed(b *testing.B) {
+	bar := NewBar(b.N)
+	b.RunParallel(func(pb *testing.PB) {
+		for n := 0; pb.Next(); n++ {
+			bar.SetRelaxed(n)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package uiprogress
+
+// Renderer draws the lines of a frame of bars, in place of the terminal output of a progress container
+type Renderer interface {
+	Render(lines []string)
+}
+
+// RendererFunc is an adapter to use an ordinary function as a Renderer
+type RendererFunc func(lines []string)
+
+// Render calls f(lines)
+func (f RendererFunc) Render(lines []string) {
+	f(lines)
+}
+
+// renderer returns the Renderer of the container, or RenderFunc as a Renderer, or nil to render to Out.
+// The caller must hold the lock.
+func (p *Progress) renderer() Renderer {
+	if p.Renderer != nil {
+		return p.Renderer
+	}
+	if p.RenderFunc != nil {
+		return RendererFunc(p.RenderFunc)
+	}
+	return nil
+}
+
+// RenderFrame advances the timer bars and returns the current frame as lines, without writing anything, for drawing
+// the bars in another user interface. The progress doesn't need to be started.
+func (p *Progress) RenderFrame() []string {
+	p.updateTimers()
+	return p.Lines()
+}
@@ -0,0 +1,38 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+// frameRecorder is a Renderer keeping the last frame
+type frameRecorder struct {
+	lines []string
+}
+
+func (r *frameRecorder) Render(lines []string) { r.lines = lines }
+
+func TestRenderer(t *testing.T) {
+	p := New()
+	rec := &frameRecorder{}
+	p.Renderer = rec
+	p.RenderFunc = func([]string) { t.Fatal("want", "Renderer used over RenderFunc") }
+	p.AddBar(10)
+	p.render()
+	if len(rec.lines) != 1 {
+		t.Fatal("want", 1, "line", "got", rec.lines)
+	}
+}
+
+func TestRenderFrame(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.clock = clk
+	bar := p.AddTimerBar(time.Second)
+	clk.Sleep(time.Second / 2)
+
+	frame := p.RenderFrame()
+	if len(frame) != 1 || frame[0] != bar.String() || bar.CompletedPercent() != 50 {
+		t.Fatal("want", "frame of the timer bar half way", "got", frame, bar.CompletedPercent())
+	}
+}
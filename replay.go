@@ -0,0 +1,47 @@
+package uiprogress
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Replay replays the events recorded from r on the default progress container, see Progress.Replay
+func Replay(r io.Reader, speed float64) error {
+	return defaultProgress.Replay(r, speed)
+}
+
+// Replay re-renders the progress recorded as the JSON lines of an emitter returned by NewJSONEmitter, for demos, bug
+// reports and regression tests of the rendering. A bar is added to the container for each bar of the recording and
+// set to the recorded values, spaced by the recorded time divided by speed: a speed of 2 replays twice as fast, and
+// a speed of 0 or less without waiting. The container renders the replay once started. Replay returns after the last
+// event, or with the error decoding the recording.
+func (p *Progress) Replay(r io.Reader, speed float64) error {
+	p.mtx.RLock()
+	clk := p.clock
+	p.mtx.RUnlock()
+
+	dec := json.NewDecoder(r)
+	bars := make(map[int]*Bar)
+	var last time.Time
+	for {
+		var e Event
+		if err := dec.Decode(&e); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d := e.Time.Sub(last); !last.IsZero() && d > 0 && speed > 0 {
+			clk.Sleep(time.Duration(float64(d) / speed))
+		}
+		last = e.Time
+
+		bar := bars[e.ID]
+		if bar == nil {
+			bar = p.AddBar64(e.Total, WithName(e.Bar))
+			bars[e.ID] = bar
+		}
+		bar.SetTotal64(e.Total)
+		bar.Set64(e.Current)
+	}
+}
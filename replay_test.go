@@ -0,0 +1,38 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplay(t *testing.T) {
+	var log bytes.Buffer
+	rec := New()
+	rec.clock = &fakeClock{now: time.Unix(0, 0)}
+	rec.Emitter = NewJSONEmitter(&log)
+	bar := rec.AddNamedBar("download", 10)
+	for _, d := range []time.Duration{0, time.Second, time.Second * 2} {
+		rec.clock.Sleep(d)
+		bar.Incr()
+		rec.emit()
+	}
+
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.clock = clk
+	if err := p.Replay(&log, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := clk.now.Sub(time.Unix(0, 0)); got != time.Millisecond*1500 {
+		t.Fatal("want", time.Millisecond*1500, "got", got)
+	}
+	if len(p.Bars) != 1 || p.Bars[0].Name() != "download" || p.Bars[0].Current() != 3 {
+		t.Fatal("want", "download at 3", "got", p.Bars)
+	}
+
+	if err := p.Replay(strings.NewReader("{"), 1); err == nil {
+		t.Fatal("want", "an error for a broken recording", "got", nil)
+	}
+}
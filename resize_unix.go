@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize notifies resize on SIGWINCH, and cont on SIGCONT when the process resumes after being suspended,
+// until stop is closed
+func watchResize(s TerminalSizer, _ Clock, resize, cont chan<- struct{}, stop <-chan struct{}) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGWINCH, syscall.SIGCONT)
+	defer signal.Stop(sigs)
+	for {
+		select {
+		case <-stop:
+			return
+		case sig := <-sigs:
+			if sig == syscall.SIGCONT {
+				// the terminal may have been resized while the process was stopped
+				invalidate(s)
+				notifyResize(cont)
+				continue
+			}
+			notifyResize(resize)
+		}
+	}
+}
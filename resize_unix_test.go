@@ -0,0 +1,48 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWatchResize(t *testing.T) {
+	p := newTestProgress(&syncBuffer{})
+	p.Sizer = TerminalSizerFunc(func() (int, int, error) { return 60, 20, nil })
+	p.Start()
+	defer p.Stop()
+	time.Sleep(time.Millisecond * 10)
+
+	syscall.Kill(os.Getpid(), syscall.SIGWINCH)
+	bar := p.Bars[0]
+	width := 0
+	for i := 0; i < 100 && width != 40; i++ {
+		time.Sleep(time.Millisecond * 10)
+		bar.mtx.RLock()
+		width = bar.Width
+		bar.mtx.RUnlock()
+	}
+	if width != 40 {
+		t.Fatal("want", 40, "got", width)
+	}
+}
+
+func TestRepaintOnContinue(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.Sizer = FixedSizer(60, 20)
+	p.Start()
+	defer p.Stop()
+	time.Sleep(time.Millisecond * 10)
+	waitFor(t, func() bool { return strings.HasSuffix(out.String(), "\n") })
+
+	// the frame left above the output of the shell isn't erased, the bars are drawn anew below it
+	n := len(out.String())
+	syscall.Kill(os.Getpid(), syscall.SIGCONT)
+	waitFor(t, func() bool { return strings.Contains(out.String()[n-1:], "\n[") })
+}
@@ -0,0 +1,28 @@
+package uiprogress
+
+import "time"
+
+// resizePollInterval is how often the console size is polled for changes, Windows has no resize signal
+var resizePollInterval = time.Millisecond * 250
+
+// watchResize notifies resize when the width reported by s changes, polled on the ticks of clk, until stop is closed.
+// Windows processes aren't suspended by the terminal, cont is never notified.
+func watchResize(s TerminalSizer, clk Clock, resize, _ chan<- struct{}, stop <-chan struct{}) {
+	last, _, _ := s.Size()
+	ticker := clk.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C():
+			invalidate(s)
+			width, _, err := s.Size()
+			if err != nil || width == last {
+				continue
+			}
+			last = width
+			notifyResize(resize)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package uiprogress
+
+import "bytes"
+
+// SegmentState is the state of a segment of a segmented bar
+type SegmentState int
+
+const (
+	// SegmentPending is the state of a segment not started yet
+	SegmentPending SegmentState = iota
+
+	// SegmentActive is the state of a segment in progress
+	SegmentActive
+
+	// SegmentDone is the state of a completed segment
+	SegmentDone
+
+	// SegmentFailed is the state of a segment that failed
+	SegmentFailed
+)
+
+// SegmentStyle is the character and color the cells of a segment are rendered with
+type SegmentStyle struct {
+	Char  rune
+	Color Color
+}
+
+// SegmentStyles are the styles of the segments of segmented bars for each state
+var SegmentStyles = map[SegmentState]SegmentStyle{
+	SegmentPending: {Char: '-'},
+	SegmentActive:  {Char: '>', Color: ColorYellow},
+	SegmentDone:    {Char: '=', Color: ColorGreen},
+	SegmentFailed:  {Char: 'x', Color: ColorRed},
+}
+
+// SetSegments divides the track of the bar into n segments, all pending, rendered in the style of their state
+// instead of the fill. The completion of the bar is still its current value, for the percent and ETA decorators.
+// A count of 0 renders the bar unsegmented again.
+func (b *Bar) SetSegments(n int) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.segments = nil
+	if n > 0 {
+		b.segments = make([]SegmentState, n)
+	}
+	b.markDirty()
+	return b
+}
+
+// SetSegmentState sets the state of segment i, for example as the chunks of a transfer start, complete or fail.
+// Indexes outside the segments set with SetSegments are ignored.
+func (b *Bar) SetSegmentState(i int, state SegmentState) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if i >= 0 && i < len(b.segments) && b.segments[i] != state {
+		b.segments[i] = state
+		b.markDirty()
+	}
+	return b
+}
+
+// Segments returns the states of the segments of the bar, or nil when it isn't segmented
+func (b *Bar) Segments() []SegmentState {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return append([]SegmentState(nil), b.segments...)
+}
+
+// renderSegments renders the cells of track between its ends in the style of the segment they fall in, and the
+// cells with a glyph in marks with it
+func (t Theme) renderSegments(track []Style, states []SegmentState, marks []rune) []byte {
+	start, end := trackBounds(track)
+	var buf bytes.Buffer
+	current := ColorDefault
+	for i, style := range track {
+		c, char := t.color(style), t.char(style)
+		if i >= start && i < end {
+			s := SegmentStyles[states[(i-start)*len(states)/(end-start)]]
+			c, char = s.Color, s.Char
+		}
+		if i < len(marks) && marks[i] != 0 {
+			char = marks[i]
+		}
+		if c != current {
+			if current != ColorDefault {
+				buf.WriteString(colorReset)
+			}
+			buf.WriteString(string(c))
+			current = c
+		}
+		buf.WriteRune(char)
+	}
+	if current != ColorDefault {
+		buf.WriteString(colorReset)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,23 @@
+package uiprogress
+
+import "testing"
+
+func TestSegments(t *testing.T) {
+	b := NewBar(100)
+	b.Width = 10
+	b.SetSegments(4).SetSegmentState(0, SegmentDone).SetSegmentState(1, SegmentFailed).SetSegmentState(2, SegmentActive)
+	b.SetSegmentState(9, SegmentDone)
+
+	want := "[" + ColorGreen.Paint("==") + ColorRed.Paint("xx") + ColorYellow.Paint(">>") + "--]"
+	if got := b.String(); got != want {
+		t.Fatalf("want %q got %q", want, got)
+	}
+	if got := b.Segments(); len(got) != 4 || got[3] != SegmentPending {
+		t.Fatal("want", 4, "segments ending pending, got", got)
+	}
+
+	b.SetSegments(0)
+	if got, want := b.String(), "[--------]"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
@@ -0,0 +1,27 @@
+package uiprogress
+
+import (
+	"io"
+	"os"
+)
+
+// StderrFallback is the default of Progress.StderrFallback
+var StderrFallback = true
+
+// stdoutRedirected reports whether w is stdout while stdout is redirected away from the terminal stderr is still
+// attached to, as in `mytool | jq`. tty reports whether a file descriptor refers to a terminal.
+func stdoutRedirected(w io.Writer, stdout, stderr *os.File, tty func(fd uintptr) bool) bool {
+	f, ok := w.(*os.File)
+	if !ok || f != stdout {
+		return false
+	}
+	return !tty(stdout.Fd()) && tty(stderr.Fd())
+}
+
+// selectSink renders to stderr in place of a redirected stdout when StderrFallback is set, leaving the piped output
+// clean. The caller must hold the lock.
+func (p *Progress) selectSink() {
+	if p.StderrFallback && stdoutRedirected(p.Out, os.Stdout, os.Stderr, isTerminal) {
+		p.Out = os.Stderr
+	}
+}
@@ -0,0 +1,37 @@
+package uiprogress
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestStdoutRedirected(t *testing.T) {
+	stdout, stderr := os.NewFile(10, "stdout"), os.NewFile(11, "stderr")
+	ttys := func(fds ...uintptr) func(uintptr) bool {
+		return func(fd uintptr) bool {
+			for _, tty := range fds {
+				if fd == tty {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	if !stdoutRedirected(stdout, stdout, stderr, ttys(11)) {
+		t.Fatal("want redirected stdout with stderr on a terminal")
+	}
+	if stdoutRedirected(stdout, stdout, stderr, ttys(10, 11)) {
+		t.Fatal("want stdout on a terminal kept")
+	}
+	if stdoutRedirected(stdout, stdout, stderr, ttys()) {
+		t.Fatal("want stdout kept without a terminal")
+	}
+	if stdoutRedirected(stderr, stdout, stderr, ttys(11)) {
+		t.Fatal("want writers other than stdout kept")
+	}
+	if stdoutRedirected(&bytes.Buffer{}, stdout, stderr, ttys(11)) {
+		t.Fatal("want buffers kept")
+	}
+}
@@ -0,0 +1,52 @@
+package uiprogress
+
+import "time"
+
+// BarState is a copy of the state of a bar at one point in time
+type BarState struct {
+	ID      int
+	Name    string
+	Current int64
+	Total   int64
+
+	// TimeStarted is the time the bar started and Elapsed the time elapsed up to its last update
+	TimeStarted time.Time
+	Elapsed     time.Duration
+
+	// Rate is the smoothed rate of progress in units per second
+	Rate float64
+
+	Completed bool
+
+	// Err is the error the bar failed with, or nil
+	Err error
+}
+
+// State returns a copy of the state of the bar
+func (b *Bar) State() BarState {
+	b.mtx.RLock()
+	s := BarState{
+		ID:          b.id,
+		Name:        b.name,
+		Current:     b.current,
+		Total:       b.Total64(),
+		TimeStarted: b.TimeStarted,
+		Elapsed:     b.timeElapsed,
+		Completed:   b.completed(),
+		Err:         b.err,
+	}
+	b.mtx.RUnlock()
+	s.Rate = b.Rate()
+	return s
+}
+
+// Snapshot returns a copy of the state of the bars of the container, in the order of Bars. Safe to call while rendering.
+func (p *Progress) Snapshot() []BarState {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	states := make([]BarState, len(p.Bars))
+	for i, bar := range p.Bars {
+		states[i] = bar.State()
+	}
+	return states
+}
@@ -0,0 +1,22 @@
+package uiprogress
+
+import "testing"
+
+func TestSnapshot(t *testing.T) {
+	p := New()
+	p.AddBar(10)
+	p.AddNamedBar("done", 5).Set(5)
+
+	states := p.Snapshot()
+	if len(states) != 2 {
+		t.Fatal("want", 2, "got", len(states))
+	}
+	if s := states[1]; s.ID != 1 || s.Name != "done" || s.Current != 5 || s.Total != 5 || !s.Completed || s.TimeStarted.IsZero() {
+		t.Fatal("want", "state of the completed bar", "got", s)
+	}
+
+	p.Bars[0].Set(3)
+	if states[0].Current != 0 {
+		t.Fatal("want", "snapshot unchanged by later updates", "got", states[0].Current)
+	}
+}
@@ -0,0 +1,39 @@
+package uiprogress
+
+// SortFunc reports whether bar a should be rendered before bar b
+type SortFunc func(a, b *Bar) bool
+
+// SortByPercent renders the most complete bars first
+func SortByPercent(a, b *Bar) bool {
+	return a.CompletedPercent() > b.CompletedPercent()
+}
+
+// SortByID renders the bars in the order they were added
+func SortByID(a, b *Bar) bool {
+	return a.ID() < b.ID()
+}
+
+// SortByLastUpdated renders the most recently updated bars first
+func SortByLastUpdated(a, b *Bar) bool {
+	return a.LastUpdated().After(b.LastUpdated())
+}
+
+// Reverse returns a SortFunc rendering the bars in the reverse order of less
+func Reverse(less SortFunc) SortFunc {
+	return func(a, b *Bar) bool {
+		return less(b, a)
+	}
+}
+
+// SortCompletedLast renders the completed bars after the incomplete ones, keeping the order within each.
+// Use Reverse(SortCompletedLast) to render completed bars first.
+func SortCompletedLast(a, b *Bar) bool {
+	return !a.Completed() && b.Completed()
+}
+
+// SetSort sets the order the bars are rendered in, safe to call while rendering. A nil less renders the bars in the order of Bars.
+func (p *Progress) SetSort(less SortFunc) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.SortBy = less
+}
@@ -0,0 +1,68 @@
+package uiprogress
+
+import "strings"
+
+// RateHistory is the number of rate samples a bar keeps for its sparkline
+var RateHistory = 60
+
+// sparks are the characters of a sparkline from the lowest to the highest rate
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// recordRate adds a rate sample to the history of the bar. The caller must hold the lock.
+func (b *Bar) recordRate(sample float64) {
+	if RateHistory <= 0 {
+		return
+	}
+	if len(b.rates) >= RateHistory {
+		n := copy(b.rates, b.rates[len(b.rates)-RateHistory+1:])
+		b.rates = b.rates[:n]
+	}
+	b.rates = append(b.rates, sample)
+}
+
+// Rates returns the recent rate samples of the bar in units per second, the oldest first. A sample is taken about
+// every 100ms while the bar is updated, and by the render loop of the container while it isn't.
+func (b *Bar) Rates() []float64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return append([]float64(nil), b.rates...)
+}
+
+// SparklineString returns the last width rate samples of the bar as a sparkline, for example "▁▂▃▅▇", scaled to the
+// highest of them. It is empty for a width of 0 or less.
+func (b *Bar) SparklineString(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	rates := b.Rates()
+	if len(rates) > width {
+		rates = rates[len(rates)-width:]
+	}
+	var max float64
+	for _, r := range rates {
+		if r > max {
+			max = r
+		}
+	}
+	var buf strings.Builder
+	for _, r := range rates {
+		i := 0
+		if max > 0 {
+			i = int(r / max * float64(len(sparks)-1))
+		}
+		buf.WriteRune(sparks[i])
+	}
+	return buf.String()
+}
+
+// Sparkline returns a decorator rendering the throughput of the bar over its last width rate samples
+func Sparkline(width int) Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.SparklineString(width)
+	})
+}
+
+// AppendSparkline appends the sparkline of the last width rate samples to the progress bar
+func (b *Bar) AppendSparkline(width int) *Bar {
+	return b.AppendDecorator(Sparkline(width))
+}
@@ -0,0 +1,61 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSparkline(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	b := NewBar(1000).SetClock(clk)
+	b.Incr()
+	for _, n := range []int{10, 20, 40, 80, 0, 80} {
+		clk.Sleep(time.Second)
+		b.IncrBy(n)
+	}
+	if got := b.SparklineString(5); got != "▂▄█▁█" {
+		t.Fatal("want", "▂▄█▁█", "got", got)
+	}
+
+	defer func(n int) { RateHistory = n }(RateHistory)
+	RateHistory = 3
+	clk.Sleep(time.Second)
+	b.IncrBy(80)
+	if got := b.Rates(); len(got) != 3 || got[0] != 0 {
+		t.Fatal("want", "the last 3 samples", "got", got)
+	}
+}
+
+func TestSparklineWidth(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	b := NewBar(1000).SetClock(clk)
+	b.Incr()
+	clk.Sleep(time.Second)
+	b.IncrBy(10)
+	for _, width := range []int{0, -1} {
+		if got := b.SparklineString(width); got != "" {
+			t.Fatal("want", "empty sparkline for width", width, "got", got)
+		}
+	}
+}
+
+func TestSparklineStalled(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.SetClock(clk)
+	p.RefreshInterval = time.Second
+	p.RenderFunc = func([]string) {}
+	b := p.AddBar(1000)
+	b.Incr()
+	clk.Sleep(time.Second)
+	b.IncrBy(80)
+	for i := 0; i < 2; i++ {
+		p.tick(nil)
+	}
+	if got := b.SparklineString(3); got != "█▁▁" {
+		t.Fatal("want", "█▁▁", "got", got)
+	}
+	if rate := b.Rate(); rate >= 80 {
+		t.Fatal("want", "rate dropping while stalled", "got", rate)
+	}
+}
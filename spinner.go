@@ -0,0 +1,102 @@
+package uiprogress
+
+import "time"
+
+// SpinnerFrames is the default animation of spinners
+var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is the time each frame of a spinner is shown
+const spinnerInterval = time.Millisecond * 80
+
+const (
+	spinnerSpinning = iota
+	spinnerDone
+	spinnerFailed
+)
+
+// Spinner renders an animated line for tasks with no measurable progress
+type Spinner struct {
+	// Name is rendered to the right of the spinner
+	Name string
+
+	// Frames is the animation of the spinner. Defaults to SpinnerFrames
+	Frames []string
+
+	bar     *Bar
+	state   int
+	started time.Time
+}
+
+// NewSpinner returns a new spinner
+func NewSpinner(name string) *Spinner {
+	s := &Spinner{
+		Name:   name,
+		Frames: SpinnerFrames,
+		bar:    NewBar(0),
+	}
+	s.bar.noTrack = true
+	s.bar.PrependFunc(func(*Bar) string {
+		return s.String()
+	})
+	return s
+}
+
+// AddSpinner creates a new spinner and adds it to the default progress container
+func AddSpinner(name string) *Spinner {
+	return defaultProgress.AddSpinner(name)
+}
+
+// AddSpinner creates a new spinner and adds it to the container, rendered in line with the bars
+func (p *Progress) AddSpinner(name string) *Spinner {
+	s := NewSpinner(name)
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.addBar(s.bar)
+	return s
+}
+
+// Bar returns the bar the spinner is rendered with, for use with RemoveBar and decorators
+func (s *Spinner) Bar() *Bar {
+	return s.bar
+}
+
+// Done stops the spinner and marks it succeeded with SuccessMark
+func (s *Spinner) Done() {
+	s.finish(spinnerDone)
+}
+
+// Fail stops the spinner and marks it failed with FailureMark
+func (s *Spinner) Fail() {
+	s.finish(spinnerFailed)
+}
+
+func (s *Spinner) finish(state int) {
+	s.bar.mtx.Lock()
+	s.state = state
+	s.bar.mtx.Unlock()
+
+	s.bar.SetTotal(1)
+	s.bar.Set(1)
+}
+
+// String returns the current frame of the spinner, or the completion mark when done, followed by the name
+func (s *Spinner) String() string {
+	s.bar.mtx.Lock()
+	defer s.bar.mtx.Unlock()
+
+	var glyph string
+	switch s.state {
+	case spinnerDone:
+		glyph = SuccessMark
+	case spinnerFailed:
+		glyph = FailureMark
+	default:
+		if s.started.IsZero() {
+			s.started = s.bar.timeSource().Now()
+		}
+		if len(s.Frames) > 0 {
+			glyph = s.Frames[int(s.bar.timeSource().Since(s.started)/spinnerInterval)%len(s.Frames)]
+		}
+	}
+	return glyph + " " + s.Name
+}
@@ -0,0 +1,32 @@
+package uiprogress
+
+import "testing"
+
+func TestSpinner(t *testing.T) {
+	p := New()
+	s := p.AddSpinner("resolving")
+	s.Frames = []string{"*"}
+	if len(p.Bars) != 1 || p.Bars[0] != s.Bar() {
+		t.Fatal("want", "spinner rendered with the bars")
+	}
+	if got := s.Bar().String(); got != "* resolving" {
+		t.Fatal("want", "* resolving", "got", got)
+	}
+	if s.Bar().Completed() {
+		t.Fatal("want", "spinning spinner not completed")
+	}
+
+	s.Done()
+	if got := s.Bar().String(); got != "✓ resolving" {
+		t.Fatal("want", "✓ resolving", "got", got)
+	}
+	if !s.Bar().Completed() {
+		t.Fatal("want", "done spinner completed")
+	}
+
+	s = NewSpinner("fetching")
+	s.Fail()
+	if got := s.String(); got != "✗ fetching" {
+		t.Fatal("want", "✗ fetching", "got", got)
+	}
+}
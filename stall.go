@@ -0,0 +1,68 @@
+package uiprogress
+
+import "time"
+
+// StalledText is rendered after a bar that received no update for longer than its stall timeout
+var StalledText = "stalled"
+
+// SetStallTimeout makes the bar stalled when it receives no update for d, rendering StalledText after it until the
+// next update. onStall, when not nil, is called once each time the bar stalls, for example to retry a dead
+// connection. Stalls are detected by the render loop of the progress container. A d of 0 disables the detection.
+func (b *Bar) SetStallTimeout(d time.Duration, onStall func(b *Bar)) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.stallTimeout = d
+	b.onStall = onStall
+	b.stallSince = b.timeSource().Now()
+	if b.stalled {
+		b.stalled = false
+		b.markDirty()
+	}
+	return b
+}
+
+// Stalled reports whether the bar received no update for longer than its stall timeout
+func (b *Bar) Stalled() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.stalled
+}
+
+// checkStall marks the bar as stalled when it received no update for its stall timeout at now, and reports whether
+// it just stalled. It must be called without the lock held.
+func (b *Bar) checkStall(now time.Time) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.stallTimeout <= 0 || b.stalled || b.completed() || b.err != nil {
+		return false
+	}
+	last := b.lastUpdated
+	if last.Before(b.stallSince) {
+		last = b.stallSince
+	}
+	if now.Sub(last) < b.stallTimeout {
+		return false
+	}
+	b.stalled = true
+	b.markDirty()
+	return true
+}
+
+// checkStalls detects the stalled bars of the container and calls their stall callbacks
+func (p *Progress) checkStalls() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	now := p.clock.Now()
+	p.mtx.RUnlock()
+	for _, bar := range bars {
+		if !bar.checkStall(now) {
+			continue
+		}
+		bar.mtx.RLock()
+		onStall := bar.onStall
+		bar.mtx.RUnlock()
+		if onStall != nil {
+			onStall(bar)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStallTimeout(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.SetClock(clk)
+	p.RefreshInterval = time.Second
+	p.RenderFunc = func([]string) {}
+	stalls := 0
+	bar := p.AddBar(10).SetStallTimeout(time.Second*3, func(*Bar) { stalls++ })
+	bar.Incr()
+
+	for i := 0; i < 5; i++ {
+		p.tick(nil)
+	}
+	if !bar.Stalled() || stalls != 1 {
+		t.Fatal("want", "a single stall", "got", bar.Stalled(), stalls)
+	}
+	if !strings.HasSuffix(bar.String(), ColorYellow.Paint(StalledText)) {
+		t.Fatal("want", StalledText, "got", bar.String())
+	}
+
+	bar.Incr()
+	if bar.Stalled() || strings.Contains(bar.String(), StalledText) {
+		t.Fatal("want", "the stall cleared by an update", "got", bar.String())
+	}
+}
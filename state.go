@@ -0,0 +1,75 @@
+package uiprogress
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// savedState is the JSON document written by SaveState
+type savedState struct {
+	Bars []savedBar `json:"bars"`
+}
+
+// savedBar is the position of a bar written by SaveState
+type savedBar struct {
+	Name    string `json:"name"`
+	Current int64  `json:"current"`
+	Total   int64  `json:"total"`
+}
+
+// SaveState writes the name, current value and total of the named bars of the container to w as JSON, for LoadState
+// to restore them after the process restarts. Unnamed, timer and overall bars aren't saved.
+func (p *Progress) SaveState(w io.Writer) error {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	p.mtx.RUnlock()
+
+	state := savedState{Bars: []savedBar{}}
+	for _, bar := range bars {
+		bar.mtx.RLock()
+		if bar.name != "" && bar.timer == 0 && !bar.overall {
+			state.Bars = append(state.Bars, savedBar{Name: bar.name, Current: bar.current, Total: bar.Total64()})
+		}
+		bar.mtx.RUnlock()
+	}
+	return json.NewEncoder(w).Encode(state)
+}
+
+// LoadState restores the bars saved by SaveState from r. A bar of the container with the same name is moved to the
+// saved position, other bars are added. The restored progress doesn't count towards the rate of the bars.
+func (p *Progress) LoadState(r io.Reader) error {
+	var state savedState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return err
+	}
+	for _, saved := range state.Bars {
+		bar := p.Bar(saved.Name)
+		if bar == nil {
+			p.mtx.Lock()
+			bar = p.newBar(saved.Total, []BarOption{WithName(saved.Name)})
+			p.addBar(bar)
+			p.mtx.Unlock()
+		} else {
+			bar.SetTotal64(saved.Total)
+		}
+		if err := bar.restore(saved.Current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restore moves the bar to n and starts sampling its rate again from there, so that a bar that was already running
+// doesn't count the jump towards its rate
+func (b *Bar) restore(n int64) error {
+	defer b.notifyComplete()
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.rate, b.rateUnits, b.rateSampled = 0, 0, time.Time{}
+	if err := b.set(n); err != nil {
+		return err
+	}
+	b.startCurrent = n
+	return nil
+}
@@ -0,0 +1,60 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveState(t *testing.T) {
+	p := New()
+	p.AddNamedBar("a.iso", 100).Set(40)
+	p.AddNamedBar("b.iso", 50).Set(50)
+	p.AddBar(10).Set(5)
+
+	var buf bytes.Buffer
+	if err := p.SaveState(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"bars":[{"name":"a.iso","current":40,"total":100},{"name":"b.iso","current":50,"total":50}]}` + "\n"
+	if buf.String() != want {
+		t.Fatal("want", want, "got", buf.String())
+	}
+
+	restored := New()
+	existing := restored.AddNamedBar("b.iso", 0)
+	if err := restored.LoadState(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Bars) != 2 || !existing.Completed() {
+		t.Fatal("want", "b.iso restored in place and a.iso added", "got", restored.Snapshot())
+	}
+	if a := restored.Bar("a.iso"); a == nil || a.Current() != 40 || a.Total != 100 || a.Rate() != 0 {
+		t.Fatal("want", "a.iso at 40/100", "got", restored.Snapshot())
+	}
+}
+
+func TestLoadStateStartedBar(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	bar := p.AddNamedBar("a.iso", 10000).SetClock(clk)
+	for i := 0; i < 5; i++ {
+		bar.IncrBy(10)
+		clk.Sleep(time.Second)
+	}
+
+	if err := p.LoadState(strings.NewReader(`{"bars":[{"name":"a.iso","current":5000,"total":10000}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if bar.Current() != 5000 || bar.Rate() != 0 {
+		t.Fatal("want", "5000 and the rate sampled again", "got", bar.Current(), bar.Rate())
+	}
+	for i := 0; i < 3; i++ {
+		clk.Sleep(time.Second)
+		bar.IncrBy(10)
+	}
+	if rate := bar.Rate(); rate < 9 || rate > 11 {
+		t.Fatal("want", "rate of 10/s without the restored jump", "got", rate)
+	}
+}
@@ -0,0 +1,107 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+// TemplateData is the data a bar template is executed with
+type TemplateData struct {
+	// Name is the name of the bar
+	Name string
+
+	// Bar is the progress indicator without its ends, Width cells wide
+	Bar string
+
+	// Percent is the completed percent, for example " 42%"
+	Percent string
+
+	// Elapsed and ETA are the time elapsed and the estimated time remaining
+	Elapsed string
+	ETA     string
+
+	// Rate is the rate of progress, for example "12.5/s"
+	Rate string
+
+	// Current and Total are the current and total values of the bar
+	Current int64
+	Total   int64
+
+	// Error is the failure of the bar, empty unless it failed
+	Error string
+}
+
+// SetTemplate formats the bar with the text/template tpl instead of its decorators, for example
+// "{{.Name}} [{{.Bar}}] {{.Percent}} {{.ETA}}". The fields available are those of TemplateData.
+// An empty tpl restores the decorators.
+func (b *Bar) SetTemplate(tpl string) error {
+	var t *template.Template
+	if tpl != "" {
+		var err error
+		if t, err = template.New("bar").Parse(tpl); err != nil {
+			return err
+		}
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.tpl = t
+	b.markDirty()
+	return nil
+}
+
+// template returns the template of the bar, or nil
+func (b *Bar) template() *template.Template {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.tpl
+}
+
+// trackPlaceholder stands in for the track in the output of a template, which otherwise has no NUL
+const trackPlaceholder = "\x00"
+
+// templateParts lays the bar out with tpl. A WidthAuto bar takes the width left by the rest of the template.
+func (b *Bar) templateParts(tpl *template.Template, width int) []linePart {
+	data := TemplateData{
+		Name:    b.Name(),
+		Percent: b.CompletedPercentString(),
+		Elapsed: b.TimeElapsedString(),
+		ETA:     b.ETAString(),
+		Rate:    b.RateString(),
+		Current: b.Current64(),
+		Total:   b.Total64(),
+		Error:   b.ErrorString(),
+	}
+
+	var buf bytes.Buffer
+	if width == WidthAuto {
+		b.mtx.RLock()
+		cols := b.lineWidth
+		b.mtx.RUnlock()
+		width = Width
+		if cols > 0 {
+			if err := tpl.Execute(&buf, data); err != nil {
+				return []linePart{{text: err.Error()}}
+			}
+			width = cols - strutil.Width(buf.String())
+			buf.Reset()
+		}
+	}
+
+	// the track of the bar includes both ends, which the template renders itself
+	track := b.track(width + 2)
+	if len(track) > 2 {
+		data.Bar = trackPlaceholder
+	}
+	if err := tpl.Execute(&buf, data); err != nil {
+		return []linePart{{text: err.Error()}}
+	}
+	texts := strings.Split(buf.String(), trackPlaceholder)
+	parts := []linePart{{text: texts[0]}}
+	for _, text := range texts[1:] {
+		parts = append(parts, linePart{track: track[1 : len(track)-1]}, linePart{text: text})
+	}
+	return parts
+}
@@ -0,0 +1,34 @@
+package uiprogress
+
+import (
+	"testing"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+func TestSetTemplate(t *testing.T) {
+	b := NewBar(10).SetName("copy")
+	b.Width = 4
+	b.Set(5)
+	if err := b.SetTemplate("{{.Name}} [{{.Bar}}] {{.Percent}} {{.Current}}/{{.Total}}"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "copy [=>--]  50% 5/10"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+
+	b.Width = WidthAuto
+	b.setLineWidth(30)
+	if got := strutil.Width(b.String()); got != 30 {
+		t.Fatal("want", 30, "got", got)
+	}
+
+	if err := b.SetTemplate("{{.Name"); err == nil {
+		t.Fatal("want", "parse error")
+	}
+	b.Width = 6
+	b.SetTemplate("")
+	if got, want := b.String(), "[=>--]"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
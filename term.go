@@ -0,0 +1,110 @@
+package uiprogress
+
+import (
+	"io"
+	"sync"
+)
+
+// WidthPadding is the number of cells ChangeWidth leaves next to fixed width bars for their decorators
+var WidthPadding = 20
+
+// FallbackColumns is the terminal width assumed when the detected one isn't positive or is wider than MaxColumns,
+// as reported by some serial consoles and broken ioctls
+var FallbackColumns = 80
+
+// MaxColumns is the widest terminal width taken as valid
+var MaxColumns = 4096
+
+// TerminalSizer reports the size of the terminal in columns and rows
+type TerminalSizer interface {
+	Size() (width, height int, err error)
+}
+
+// TerminalSizerFunc is an adapter to use an ordinary function as a TerminalSizer
+type TerminalSizerFunc func() (width, height int, err error)
+
+// Size calls f()
+func (f TerminalSizerFunc) Size() (width, height int, err error) {
+	return f()
+}
+
+// FixedSizer returns a TerminalSizer always reporting the given size, for tests and outputs that aren't terminals
+func FixedSizer(width, height int) TerminalSizer {
+	return TerminalSizerFunc(func() (int, int, error) {
+		return width, height, nil
+	})
+}
+
+// CachedSizer is a TerminalSizer reporting the size last read from another sizer until Invalidate is called, saving
+// a system call per frame. Progress containers invalidate their sizer when the terminal is resized.
+type CachedSizer struct {
+	sizer TerminalSizer
+
+	mtx           *sync.Mutex
+	valid         bool
+	width, height int
+	err           error
+}
+
+// NewCachedSizer returns a CachedSizer for s
+func NewCachedSizer(s TerminalSizer) *CachedSizer {
+	return &CachedSizer{sizer: s, mtx: &sync.Mutex{}}
+}
+
+// Size returns the cached size, reading it the first time after Invalidate
+func (c *CachedSizer) Size() (width, height int, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if !c.valid {
+		c.width, c.height, c.err = c.sizer.Size()
+		c.valid = true
+	}
+	return c.width, c.height, c.err
+}
+
+// Invalidate discards the cached size
+func (c *CachedSizer) Invalidate() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.valid = false
+}
+
+// invalidate discards the size cached by s, if it caches it
+func invalidate(s TerminalSizer) {
+	if c, ok := s.(interface{ Invalidate() }); ok {
+		c.Invalidate()
+	}
+}
+
+// fdOf returns the file descriptor of w, when it has one
+func fdOf(w io.Writer) (uintptr, bool) {
+	f, ok := w.(interface {
+		Fd() uintptr
+	})
+	if !ok {
+		return 0, false
+	}
+	return f.Fd(), true
+}
+
+// validSize returns the detected terminal size with invalid columns replaced by FallbackColumns and invalid rows by 0,
+// for an unknown height
+func validSize(cols, rows int) (int, int) {
+	if cols <= 0 || cols > MaxColumns {
+		cols = FallbackColumns
+	}
+	if rows < 0 {
+		rows = 0
+	}
+	return cols, rows
+}
+
+// barWidth returns the width of fixed width bars on a terminal cols wide, leaving WidthPadding cells for the
+// decorators but no narrower than MinWidth
+func barWidth(cols int) int {
+	width := cols - WidthPadding
+	if width < MinWidth {
+		width = MinWidth
+	}
+	return width
+}
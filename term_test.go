@@ -0,0 +1,124 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCachedSizer(t *testing.T) {
+	calls := 0
+	s := NewCachedSizer(TerminalSizerFunc(func() (int, int, error) {
+		calls++
+		return 80 + calls, 24, nil
+	}))
+	s.Size()
+	if width, _, _ := s.Size(); width != 81 || calls != 1 {
+		t.Fatal("want", "a single read of width 81", "got", calls, width)
+	}
+
+	p := New()
+	p.Sizer = s
+	p.ChangeWidth()
+	if width, _, _ := s.Size(); width != 82 {
+		t.Fatal("want", 82, "got", width)
+	}
+}
+
+func TestFixedSizer(t *testing.T) {
+	p := New()
+	p.Width = WidthAuto
+	p.Sizer = FixedSizer(40, 10)
+	p.AddBar(10)
+	if got := len(p.Lines()[0]); got != 40 {
+		t.Fatal("want", 40, "got", got)
+	}
+}
+
+func TestClipRows(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	p.lw.setOut(out)
+	p.Sizer = FixedSizer(100, 4)
+	for i := 0; i < 5; i++ {
+		p.AddBar(10)
+	}
+	p.ChangeWidth()
+	p.mtx.Lock()
+	p.render()
+	p.mtx.Unlock()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 || lines[2] != "+4 hidden" {
+		t.Fatalf("want 2 bars and the hidden count, got %q", lines)
+	}
+}
+
+func TestChangeWidthInvalidSize(t *testing.T) {
+	tests := []struct {
+		cols, rows int
+		width      int
+		gotCols    int
+		gotRows    int
+	}{
+		{0, 24, FallbackColumns - WidthPadding, FallbackColumns, 24},
+		{-5, -1, FallbackColumns - WidthPadding, FallbackColumns, 0},
+		{1 << 20, 24, FallbackColumns - WidthPadding, FallbackColumns, 24},
+		{5, 24, MinWidth, 5, 24},
+		{200, 50, 200 - WidthPadding, 200, 50},
+	}
+	for _, tt := range tests {
+		p := New()
+		p.Sizer = FixedSizer(tt.cols, tt.rows)
+		bar := p.AddBar(10)
+		p.ChangeWidth()
+		if bar.Width != tt.width || p.cols != tt.gotCols || p.rows != tt.gotRows {
+			t.Fatal("want", tt.width, tt.gotCols, tt.gotRows, "got", bar.Width, p.cols, p.rows)
+		}
+		if got := bar.String(); got == "" {
+			t.Fatal("want bar rendered for", tt.cols, "columns")
+		}
+	}
+}
+
+func TestChangeWidthNilSizer(t *testing.T) {
+	p := New()
+	p.Sizer = nil
+	bar := p.AddBar(10)
+	p.ChangeWidth()
+	if bar.Width != Width {
+		t.Fatal("want", Width, "got", bar.Width)
+	}
+}
+
+func TestWatchResizeNilSizer(t *testing.T) {
+	p := newTestProgress(&syncBuffer{})
+	p.Sizer = nil
+	p.Start()
+	defer p.Stop()
+	p.WatchResize()
+	p.mtx.RLock()
+	watching := p.watching
+	p.mtx.RUnlock()
+	if watching {
+		t.Fatal("want", "no resize watcher without a Sizer")
+	}
+}
+
+func TestGetTerminalWidth(t *testing.T) {
+	defer func(s TerminalSizer) { Sizer = s }(Sizer)
+	tests := []struct {
+		cols, width int
+	}{
+		{0, FallbackColumns - WidthPadding},
+		{-5, FallbackColumns - WidthPadding},
+		{1 << 20, FallbackColumns - WidthPadding},
+		{5, MinWidth},
+		{200, 200 - WidthPadding},
+	}
+	for _, tt := range tests {
+		Sizer = FixedSizer(tt.cols, 24)
+		if width, err := GetTerminalWidth(); err != nil || width != tt.width {
+			t.Fatal("want", tt.width, "for", tt.cols, "columns", "got", width, err)
+		}
+	}
+}
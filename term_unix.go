@@ -0,0 +1,51 @@
+//go:build !windows
+// +build !windows
+
+package uiprogress
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xpixel uint16
+	ypixel uint16
+}
+
+// terminalSizer reads the terminal size with the TIOCGWINSZ ioctl on stdout, falling back to the controlling terminal
+type terminalSizer struct{}
+
+func (terminalSizer) Size() (width, height int, err error) {
+	if w, h, ok := ioctlSize(os.Stdout.Fd()); ok {
+		return w, h, nil
+	}
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return 0, 0, ErrExecFail
+	}
+	defer tty.Close()
+	if w, h, ok := ioctlSize(tty.Fd()); ok {
+		return w, h, nil
+	}
+	return 0, 0, ErrExecFail
+}
+
+func ioctlSize(fd uintptr) (width, height int, ok bool) {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 || ws.cols == 0 {
+		return 0, 0, false
+	}
+	return int(ws.cols), int(ws.rows), true
+}
+
+// isTerminal reports whether fd refers to a terminal
+func isTerminal(fd uintptr) bool {
+	var ws winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return errno == 0
+}
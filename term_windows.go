@@ -0,0 +1,49 @@
+package uiprogress
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+
+type coord struct {
+	x int16
+	y int16
+}
+
+type smallRect struct {
+	left   int16
+	top    int16
+	right  int16
+	bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+	size              coord
+	cursorPosition    coord
+	attributes        uint16
+	window            smallRect
+	maximumWindowSize coord
+}
+
+// terminalSizer reads the size of the console window with GetConsoleScreenBufferInfo
+type terminalSizer struct{}
+
+func (terminalSizer) Size() (width, height int, err error) {
+	var csbi consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(os.Stdout.Fd(), uintptr(unsafe.Pointer(&csbi)))
+	if r == 0 {
+		return 0, 0, ErrExecFail
+	}
+	width = int(csbi.window.right-csbi.window.left) + 1
+	height = int(csbi.window.bottom-csbi.window.top) + 1
+	return width, height, nil
+}
+
+// isTerminal reports whether fd refers to a console
+func isTerminal(fd uintptr) bool {
+	_, err := getConsoleMode(syscall.Handle(fd))
+	return err == nil
+}
@@ -0,0 +1,223 @@
+package uiprogress
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Color is an ANSI escape sequence that sets the color of the text following it
+type Color string
+
+// Foreground colors. ColorDefault leaves the text uncolored.
+const (
+	ColorDefault Color = ""
+	ColorBlack   Color = "\x1b[30m"
+	ColorRed     Color = "\x1b[31m"
+	ColorGreen   Color = "\x1b[32m"
+	ColorYellow  Color = "\x1b[33m"
+	ColorBlue    Color = "\x1b[34m"
+	ColorMagenta Color = "\x1b[35m"
+	ColorCyan    Color = "\x1b[36m"
+	ColorWhite   Color = "\x1b[37m"
+)
+
+// colorReset resets the colors to the terminal defaults
+const colorReset = "\x1b[0m"
+
+// Paint returns s colored with c and followed by a reset
+func (c Color) Paint(s string) string {
+	if c == ColorDefault {
+		return s
+	}
+	return string(c) + s + colorReset
+}
+
+// Theme is the set of characters and colors the progress indicator is rendered with.
+// Colors don't count towards the width of the bar.
+type Theme struct {
+	// Fill is the character representing completed progress
+	Fill rune
+
+	// Head is the character that moves when progress is updated
+	Head rune
+
+	// Empty is the character that represents the empty progress
+	Empty rune
+
+	// LeftEnd is character in the left most part of the progress indicator
+	LeftEnd rune
+
+	// RightEnd is character in the right most part of the progress indicator
+	RightEnd rune
+
+	FillColor  Color
+	HeadColor  Color
+	EmptyColor Color
+	EndColor   Color
+
+	// Partials, when set, are the characters for a partly filled cell from the least to the most filled. The head
+	// is rendered with one of them so the bar advances in fractions of a cell.
+	Partials string
+}
+
+// SmoothTheme returns a theme filling the bar with blocks, advancing in eighths of a cell
+func SmoothTheme() Theme {
+	return Theme{
+		Fill:     '█',
+		Empty:    ' ',
+		LeftEnd:  '|',
+		RightEnd: '|',
+		Partials: "▏▎▍▌▋▊▉",
+	}
+}
+
+// DefaultTheme is the theme built from the default characters
+func DefaultTheme() Theme {
+	return Theme{
+		Fill:     rune(Fill),
+		Head:     rune(Head),
+		Empty:    rune(Empty),
+		LeftEnd:  rune(LeftEnd),
+		RightEnd: rune(RightEnd),
+	}
+}
+
+// char returns the character of the style
+func (t Theme) char(style Style) rune {
+	switch style {
+	case StyleLeftEnd:
+		return t.LeftEnd
+	case StyleRightEnd:
+		return t.RightEnd
+	case StyleFill:
+		return t.Fill
+	case StyleHead:
+		return t.Head
+	case StyleEmpty:
+		return t.Empty
+	}
+	return ' '
+}
+
+// smoothFill returns how many of the n inner cells of a track are filled at percent, and the index in Partials of
+// the partly filled cell after them, or -1 when there is none
+func (t Theme) smoothFill(n int, percent float64) (full, partial int) {
+	exact := float64(n) * percent / 100
+	full = int(exact)
+	if full >= n {
+		return n, -1
+	}
+	partial = int((exact-float64(full))*float64(utf8.RuneCountInString(t.Partials)+1)) - 1
+	return full, partial
+}
+
+// smoothTrack returns the track of a bar with a theme with partials, its head being the partly filled cell
+func (b *Bar) smoothTrack(width int, theme Theme) []Style {
+	track := make([]Style, width)
+	full, partial := theme.smoothFill(width-2, b.CompletedPercent())
+	for i := range track {
+		switch {
+		case i <= full:
+			track[i] = StyleFill
+		case i == full+1 && partial >= 0:
+			track[i] = StyleHead
+		default:
+			track[i] = StyleEmpty
+		}
+	}
+	track[0], track[width-1] = StyleLeftEnd, StyleRightEnd
+	return track
+}
+
+// trackTheme returns the theme a track of the given width is rendered with, its head being the partial character
+// for the current progress when the theme has partials
+func (b *Bar) trackTheme(width int) Theme {
+	theme := b.Theme()
+	if theme.Partials == "" || b.indeterminate() {
+		return theme
+	}
+	if _, partial := theme.smoothFill(width-2, b.CompletedPercent()); partial >= 0 {
+		theme.Head = []rune(theme.Partials)[partial]
+	}
+	return theme
+}
+
+// color returns the color of the style
+func (t Theme) color(style Style) Color {
+	switch style {
+	case StyleLeftEnd, StyleRightEnd:
+		return t.EndColor
+	case StyleFill:
+		return t.FillColor
+	case StyleHead:
+		return t.HeadColor
+	case StyleEmpty:
+		return t.EmptyColor
+	}
+	return ColorDefault
+}
+
+// render writes the track with the theme, emitting a color escape only where the color changes
+func (t Theme) render(track []Style) []byte {
+	return t.renderMarked(track, nil)
+}
+
+// renderMarked renders the track like render, with the cells that have a glyph in marks drawn with it in the color
+// of their style
+func (t Theme) renderMarked(track []Style, marks []rune) []byte {
+	var buf bytes.Buffer
+	current := ColorDefault
+	for i, style := range track {
+		if c := t.color(style); c != current {
+			if current != ColorDefault {
+				buf.WriteString(colorReset)
+			}
+			buf.WriteString(string(c))
+			current = c
+		}
+		if i < len(marks) && marks[i] != 0 {
+			buf.WriteRune(marks[i])
+			continue
+		}
+		buf.WriteRune(t.char(style))
+	}
+	if current != ColorDefault {
+		buf.WriteString(colorReset)
+	}
+	return buf.Bytes()
+}
+
+// SetTheme sets the characters and colors the bar is rendered with, in place of LeftEnd, RightEnd, Fill, Head and Empty
+func (b *Bar) SetTheme(t Theme) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.theme = &t
+	b.markDirty()
+	return b
+}
+
+// Theme returns the theme the bar is rendered with
+func (b *Bar) Theme() Theme {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	if b.theme != nil {
+		return *b.theme
+	}
+	return Theme{
+		Fill:     rune(b.Fill),
+		Head:     rune(b.Head),
+		Empty:    rune(b.Empty),
+		LeftEnd:  rune(b.LeftEnd),
+		RightEnd: rune(b.RightEnd),
+	}
+}
+
+// SetTheme sets the theme of all the bars in the container, including the ones added later
+func (p *Progress) SetTheme(t Theme) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.Theme = &t
+	for _, bar := range p.Bars {
+		bar.SetTheme(t)
+	}
+}
@@ -0,0 +1,88 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gosuri/uiprogress/util/strutil"
+)
+
+func TestBarTheme(t *testing.T) {
+	b := NewBar(100)
+	b.Width = 10
+	b.Set(50)
+	theme := Theme{Fill: '█', Head: '█', Empty: '░', LeftEnd: '│', RightEnd: '│', FillColor: ColorGreen}
+	b.SetTheme(theme)
+
+	got := b.String()
+	if plain := strutil.StripANSI(got); plain != "│████░░░░│" {
+		t.Fatal("want", "│████░░░░│", "got", plain)
+	}
+	if strutil.Width(got) != b.Width {
+		t.Fatal("want", b.Width, "got", strutil.Width(got))
+	}
+	if strings.Count(got, string(ColorGreen)) != 1 || strings.Count(got, colorReset) != 1 {
+		t.Fatalf("want a single colored run, got %q", got)
+	}
+}
+
+func TestProgressTheme(t *testing.T) {
+	p := New()
+	before := p.AddBar(10)
+	p.SetTheme(Theme{Fill: '#', Head: '#', Empty: '.', LeftEnd: '|', RightEnd: '|'})
+	after := p.AddBar(10)
+	if before.Theme().Fill != '#' || after.Theme().Fill != '#' {
+		t.Fatal("want", "theme applied to all bars")
+	}
+}
+
+func TestSmoothTheme(t *testing.T) {
+	b := NewBar(100)
+	b.Width = 10
+	b.SetTheme(SmoothTheme())
+
+	cases := []struct {
+		current int
+		want    string
+	}{
+		{0, "|        |"},
+		{50, "|████    |"},
+		{53, "|████▏   |"},
+		{57, "|████▌   |"},
+		{100, "|████████|"},
+	}
+	for _, c := range cases {
+		b.Set(c.current)
+		if got := b.String(); got != c.want {
+			t.Fatal("want", c.want, "got", got)
+		}
+	}
+	if cells := b.RenderCells(10); cells[9].Rune != '|' || cells[8].Rune != '█' {
+		t.Fatal("want", "full cells", "got", cells)
+	}
+}
+
+func TestUseTheme(t *testing.T) {
+	RegisterTheme("test", Theme{Fill: '#', Head: '#', Empty: '.', LeftEnd: '<', RightEnd: '>'})
+	defer func() {
+		themesMtx.Lock()
+		delete(themes, "test")
+		themesMtx.Unlock()
+	}()
+
+	b := NewBar(10)
+	b.Width = 7
+	b.Set(5)
+	if err := b.UseTheme("test"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "<##...>"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+	if err := b.UseTheme("missing"); err != ErrUnknownTheme {
+		t.Fatal("want", ErrUnknownTheme, "got", err)
+	}
+	if got, want := strings.Join(ThemeNames(), ","), "classic,dots,minimal,smooth,test"; got != want {
+		t.Fatal("want", want, "got", got)
+	}
+}
@@ -0,0 +1,60 @@
+package uiprogress
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownTheme is the error of UseTheme for a name no theme is registered under
+var ErrUnknownTheme = errors.New("errors: unknown theme")
+
+var (
+	// themes holds the themes registered by name
+	themes = map[string]Theme{
+		"classic": DefaultTheme(),
+		"smooth":  SmoothTheme(),
+		"dots":    {Fill: '⣿', Head: '⣿', Empty: '⣀', LeftEnd: '⢸', RightEnd: '⡇'},
+		"minimal": {Fill: '━', Head: '━', Empty: ' ', LeftEnd: ' ', RightEnd: ' '},
+	}
+	themesMtx = &sync.RWMutex{}
+)
+
+// RegisterTheme registers t under name for UseTheme, replacing the theme already registered with the name. The
+// presets "classic", "smooth", "dots" and "minimal" are registered by default.
+func RegisterTheme(name string, t Theme) {
+	themesMtx.Lock()
+	defer themesMtx.Unlock()
+	themes[name] = t
+}
+
+// LookupTheme returns the theme registered under name
+func LookupTheme(name string) (Theme, bool) {
+	themesMtx.RLock()
+	defer themesMtx.RUnlock()
+	t, ok := themes[name]
+	return t, ok
+}
+
+// ThemeNames returns the names of the registered themes in order, for example to list the choices of a flag
+func ThemeNames() []string {
+	themesMtx.RLock()
+	defer themesMtx.RUnlock()
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseTheme sets the theme of the bar to the one registered under name. It returns ErrUnknownTheme and leaves the
+// theme unchanged when there is none.
+func (b *Bar) UseTheme(name string) error {
+	t, ok := LookupTheme(name)
+	if !ok {
+		return ErrUnknownTheme
+	}
+	b.SetTheme(t)
+	return nil
+}
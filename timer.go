@@ -0,0 +1,47 @@
+package uiprogress
+
+import "time"
+
+// AddTimerBar creates a bar that fills up over the duration and adds it to the default progress container
+func AddTimerBar(d time.Duration, opts ...BarOption) *Bar {
+	return defaultProgress.AddTimerBar(d, opts...)
+}
+
+// AddTimerBar creates a bar that advances by itself with the time elapsed since it was added and completes once d has
+// elapsed, for visualizing timeouts and waits. Its current value and total are in nanoseconds.
+func (p *Progress) AddTimerBar(d time.Duration, opts ...BarOption) *Bar {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	bar := p.newBar(int64(d), opts)
+	bar.timer = d
+	bar.timerStarted = p.clock.Now()
+	p.addBar(bar)
+	return bar
+}
+
+// updateTimer sets the current value of a timer bar to the time elapsed at now. It must be called without the lock held.
+func (b *Bar) updateTimer(now time.Time) {
+	b.mtx.RLock()
+	d, started, done := b.timer, b.timerStarted, b.completed()
+	b.mtx.RUnlock()
+	if d <= 0 || done {
+		return
+	}
+	elapsed := now.Sub(started)
+	if elapsed > d {
+		elapsed = d
+	}
+	b.Set64(int64(elapsed))
+}
+
+// updateTimers advances the timer bars of the container
+func (p *Progress) updateTimers() {
+	p.mtx.RLock()
+	bars := append([]*Bar(nil), p.Bars...)
+	now := p.clock.Now()
+	p.mtx.RUnlock()
+	for _, bar := range bars {
+		bar.updateTimer(now)
+	}
+}
@@ -0,0 +1,26 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddTimerBar(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	p := New()
+	p.clock = clk
+	p.RefreshInterval = time.Millisecond * 250
+	p.RenderFunc = func([]string) {}
+	bar := p.AddTimerBar(time.Second)
+
+	p.tick(nil)
+	if got := bar.CompletedPercent(); got != 25 {
+		t.Fatal("want", 25, "got", got)
+	}
+	for i := 0; i < 4; i++ {
+		p.tick(nil)
+	}
+	if !bar.Completed() || bar.animated() {
+		t.Fatal("want", "timer bar completed after its duration", "got", bar.CompletedPercent())
+	}
+}
@@ -0,0 +1,51 @@
+package uiprogress
+
+import "fmt"
+
+// SetUnits sets the names of the units the bar counts, like "file" and "files", rendered by CountString
+func (b *Bar) SetUnits(singular, plural string) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.unit, b.units = singular, plural
+	b.markDirty()
+	return b
+}
+
+// CountString returns the current value and total of the bar in its units, for example "37/120 files", or "37 files"
+// when the bar is indeterminate. The plural unit is used unless the total, or the current value of an indeterminate
+// bar, is 1.
+func (b *Bar) CountString() string {
+	b.mtx.RLock()
+	current, total, unit, units := b.current, b.Total64(), b.unit, b.units
+	b.mtx.RUnlock()
+
+	s := fmt.Sprintf("%d/%d", current, total)
+	n := total
+	if total <= 0 {
+		s, n = fmt.Sprint(current), current
+	}
+	if n != 1 {
+		unit = units
+	}
+	if unit == "" {
+		return s
+	}
+	return s + " " + unit
+}
+
+// Counter returns a decorator rendering the current value and total in the units of the bar, see CountString
+func Counter() Decorator {
+	return DecoratorFunc(func(b *Bar) string {
+		return b.CountString()
+	})
+}
+
+// AppendCounter appends the current value and total in the units of the bar, for example "37/120 files"
+func (b *Bar) AppendCounter() *Bar {
+	return b.AppendDecorator(Counter())
+}
+
+// PrependCounter prepends the current value and total in the units of the bar, for example "37/120 files"
+func (b *Bar) PrependCounter() *Bar {
+	return b.PrependDecorator(Counter())
+}
@@ -0,0 +1,28 @@
+package uiprogress
+
+import "testing"
+
+func TestCountString(t *testing.T) {
+	for _, tt := range []struct {
+		current, total int
+		want           string
+	}{
+		{37, 120, "37/120 files"},
+		{0, 1, "0/1 file"},
+		{1, 0, "1 file"},
+		{5, 0, "5 files"},
+	} {
+		bar := NewBar(tt.total).SetUnits("file", "files")
+		bar.Set(tt.current)
+		if got := bar.CountString(); got != tt.want {
+			t.Fatal("want", tt.want, "got", got)
+		}
+	}
+
+	bar := NewBar(9).AppendCounter()
+	bar.Width = 5
+	bar.Set(5)
+	if got := bar.String(); got != "[>--] 5/9" {
+		t.Fatal("want", "[>--] 5/9", "got", got)
+	}
+}
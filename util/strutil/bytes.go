@@ -0,0 +1,21 @@
+package strutil
+
+import "fmt"
+
+// FormatBytes formats n bytes in the largest unit that keeps the value at least 1, for example "1.5 MiB".
+// Binary units (KiB, MiB) are multiples of 1024, SI units (kB, MB) multiples of 1000.
+func FormatBytes(n int64, si bool) string {
+	unit, prefixes, suffix := int64(1024), "KMGTP", "iB"
+	if si {
+		unit, prefixes, suffix = 1000, "kMGTP", "B"
+	}
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit && exp < len(prefixes)-1; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %c%s", float64(n)/float64(div), prefixes[exp], suffix)
+}
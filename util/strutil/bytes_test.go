@@ -0,0 +1,22 @@
+package strutil
+
+import "testing"
+
+func TestFormatBytes(t *testing.T) {
+	for _, tt := range []struct {
+		n    int64
+		si   bool
+		want string
+	}{
+		{512, false, "512 B"},
+		{1536, false, "1.5 KiB"},
+		{5 << 30, false, "5.0 GiB"},
+		{999, true, "999 B"},
+		{1500, true, "1.5 kB"},
+		{154300000, true, "154.3 MB"},
+	} {
+		if got := FormatBytes(tt.n, tt.si); got != tt.want {
+			t.Fatal("want", tt.want, "got", got)
+		}
+	}
+}
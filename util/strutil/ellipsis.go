@@ -0,0 +1,54 @@
+package strutil
+
+// EllipsisPosition is where Ellipsize cuts a string that doesn't fit
+type EllipsisPosition int
+
+const (
+	// EllipsisEnd keeps the beginning of the string, cutting its end
+	EllipsisEnd EllipsisPosition = iota
+
+	// EllipsisStart keeps the end of the string, cutting its beginning. Suits file paths.
+	EllipsisStart
+
+	// EllipsisMiddle keeps both the beginning and the end of the string, cutting its middle
+	EllipsisMiddle
+)
+
+// Ellipsis is the character replacing the part of a string cut by Ellipsize
+const Ellipsis = "…"
+
+// Ellipsize returns s when it fits in width cells, or s cut at pos with an ellipsis otherwise. ANSI escape
+// sequences are kept only when cutting the end.
+func Ellipsize(s string, width int, pos EllipsisPosition) string {
+	if Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	n := width - Width(Ellipsis)
+	switch pos {
+	case EllipsisStart:
+		return Ellipsis + truncateLeft(StripANSI(s), n)
+	case EllipsisMiddle:
+		plain := StripANSI(s)
+		return Truncate(plain, n-n/2) + Ellipsis + truncateLeft(plain, n/2)
+	}
+	return Truncate(s, n) + Ellipsis
+}
+
+// Fit returns s cut at pos with an ellipsis or padded with spaces to take exactly width cells
+func Fit(s string, width int, pos EllipsisPosition) string {
+	return PadRight(Ellipsize(s, width, pos), width, ' ')
+}
+
+// truncateLeft returns the longest suffix of s that fits in width cells
+func truncateLeft(s string, width int) string {
+	runes := []rune(s)
+	n, i := 0, len(runes)
+	for i > 0 && n+RuneWidth(runes[i-1]) <= width {
+		n += RuneWidth(runes[i-1])
+		i--
+	}
+	return string(runes[i:])
+}
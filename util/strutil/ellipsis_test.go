@@ -0,0 +1,25 @@
+package strutil
+
+import "testing"
+
+func TestEllipsize(t *testing.T) {
+	cases := []struct {
+		pos  EllipsisPosition
+		want string
+	}{
+		{EllipsisEnd, "/var/l…"},
+		{EllipsisStart, "…es.log"},
+		{EllipsisMiddle, "/va…log"},
+	}
+	for _, c := range cases {
+		if got := Ellipsize("/var/log/files.log", 7, c.pos); got != c.want {
+			t.Fatal("want", c.want, "got", got)
+		}
+	}
+	if got := Ellipsize("foo", 7, EllipsisMiddle); got != "foo" {
+		t.Fatal("want", "foo", "got", got)
+	}
+	if got := Fit("foo", 5, EllipsisEnd); got != "foo  " {
+		t.Fatal("want", "foo  ", "got", got)
+	}
+}
@@ -3,16 +3,22 @@ package strutil
 
 import (
 	"bytes"
+	"fmt"
+	"regexp"
 	"time"
 )
 
+// ansiEscape matches ANSI CSI escape sequences such as colors and cursor movement
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;?]*[a-zA-Z]")
+
 // PadRight returns a new string of a specified length in which the end of the current string is padded with spaces or with a specified Unicode character.
 func PadRight(str string, length int, pad byte) string {
-	if len(str) >= length {
+	width := Width(str)
+	if width >= length {
 		return str
 	}
 	buf := bytes.NewBufferString(str)
-	for i := 0; i < length-len(str); i++ {
+	for i := 0; i < length-width; i++ {
 		buf.WriteByte(pad)
 	}
 	return buf.String()
@@ -20,11 +26,12 @@ func PadRight(str string, length int, pad byte) string {
 
 // PadLeft returns a new string of a specified length in which the beginning of the current string is padded with spaces or with a specified Unicode character.
 func PadLeft(str string, length int, pad byte) string {
-	if len(str) >= length {
+	width := Width(str)
+	if width >= length {
 		return str
 	}
 	var buf bytes.Buffer
-	for i := 0; i < length-len(str); i++ {
+	for i := 0; i < length-width; i++ {
 		buf.WriteByte(pad)
 	}
 	buf.WriteString(str)
@@ -32,24 +39,20 @@ func PadLeft(str string, length int, pad byte) string {
 }
 
 // Resize resizes the string with the given length. It ellipses with '...' when the string's length exceeds
-// the desired length or pads spaces to the right of the string when length is smaller than desired
+// the desired length or pads spaces to the right of the string when length is smaller than desired.
+// The length is measured in terminal cells, so wide characters count twice.
 func Resize(s string, length uint) string {
 	n := int(length)
-	if len(s) == n {
+	width := Width(s)
+	if width == n {
 		return s
 	}
 	// Pads only when length of the string smaller than len needed
-	s = PadRight(s, n, ' ')
-	if len(s) > n {
-		b := []byte(s)
-		var buf bytes.Buffer
-		for i := 0; i < n-3; i++ {
-			buf.WriteByte(b[i])
-		}
-		buf.WriteString("...")
-		s = buf.String()
-	}
-	return s
+	if width < n {
+		return PadRight(s, n, ' ')
+	}
+	// a wide character may not fit exactly, pad to keep the length
+	return PadRight(Truncate(s, n-3), n-3, ' ') + "..."
 }
 
 // PrettyTime returns the string representation of the duration. It rounds the time duration to a second and returns a "---" when duration is 0
@@ -59,3 +62,35 @@ func PrettyTime(t time.Duration) string {
 	}
 	return (t - (t % time.Second)).String()
 }
+
+// ClockTime returns the duration rounded to a second as hours, minutes and seconds, for example "01:02:13". It returns
+// "--:--:--" when duration is 0
+func ClockTime(t time.Duration) string {
+	if t == 0 {
+		return "--:--:--"
+	}
+	t = t.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(t.Hours()), int(t.Minutes())%60, int(t.Seconds())%60)
+}
+
+// HumanTime returns the duration in words, for example "about 2 minutes". It returns "---" when duration is 0
+func HumanTime(t time.Duration) string {
+	switch {
+	case t == 0:
+		return "---"
+	case t < time.Minute:
+		return "less than a minute"
+	case t < time.Second*90:
+		return "about a minute"
+	case t.Round(time.Minute) < time.Hour:
+		return fmt.Sprintf("about %d minutes", int(t.Round(time.Minute).Minutes()))
+	case t < time.Minute*90:
+		return "about an hour"
+	}
+	return fmt.Sprintf("about %d hours", int(t.Round(time.Hour).Hours()))
+}
+
+// StripANSI returns the string with all the ANSI escape sequences removed
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
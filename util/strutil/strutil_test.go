@@ -40,3 +40,73 @@ func TestPrettyTime(t *testing.T) {
 		t.Fatal("want", "---", "got", got)
 	}
 }
+
+func TestStripANSI(t *testing.T) {
+	got := StripANSI("\x1b[32m✓1\x1b[0m \x1b[2K\x1b[1Afoo")
+	if got != "✓1 foo" {
+		t.Fatal("want", "✓1 foo", "got", got)
+	}
+}
+
+func TestWidth(t *testing.T) {
+	if got := Width("\x1b[32m✓12\x1b[0m"); got != 3 {
+		t.Fatal("want", 3, "got", got)
+	}
+	if got := PadLeft("\x1b[32m1s\x1b[0m", 5, ' '); StripANSI(got) != "   1s" {
+		t.Fatal("want", "   1s", "got", StripANSI(got))
+	}
+}
+
+func TestWideWidth(t *testing.T) {
+	for s, want := range map[string]int{
+		"日本":               4,
+		"e\u0301":          1,
+		"📦 pkg":            6,
+		"\x1b[32m漢\x1b[0m": 2,
+	} {
+		if got := Width(s); got != want {
+			t.Fatal("want", want, "for", s, "got", got)
+		}
+	}
+}
+
+func TestResizeWide(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		n    uint
+		want string
+	}{
+		{"日本", 6, "日本  "},
+		{"日本語テキスト", 7, "日本..."},
+		{"日本語テキスト", 6, "日 ..."},
+	} {
+		if got := Resize(c.s, c.n); got != c.want {
+			t.Fatalf("want %q got %q", c.want, got)
+		}
+	}
+}
+
+func TestTruncateColored(t *testing.T) {
+	s := "\x1b[32mgreen\x1b[0m \x1b[31mred\x1b[0m"
+	if got, want := Truncate(s, 3), "\x1b[32mgre\x1b[0m\x1b[31m\x1b[0m"; got != want {
+		t.Fatalf("want %q got %q", want, got)
+	}
+	if got := Truncate(s, 20); got != s {
+		t.Fatalf("want %q got %q", s, got)
+	}
+}
+
+func TestClockTime(t *testing.T) {
+	if got := ClockTime(time.Hour + time.Second*133); got != "01:02:13" {
+		t.Fatal("want", "01:02:13", "got", got)
+	}
+}
+
+func TestHumanTime(t *testing.T) {
+	if got := HumanTime(time.Second * 133); got != "about 2 minutes" {
+		t.Fatal("want", "about 2 minutes", "got", got)
+	}
+	if got := HumanTime(time.Hour * 5); got != "about 5 hours" {
+		t.Fatal("want", "about 5 hours", "got", got)
+	}
+}
@@ -0,0 +1,43 @@
+package uiprogress
+
+// Wait blocks until all the bars are complete or failed and a frame showing them so has been rendered, then stops
+// the progress, so main can defer Wait instead of sleeping before Stop. It returns right away when the progress
+// isn't started, and when it is stopped meanwhile.
+func (p *Progress) Wait() {
+	p.mtx.Lock()
+	if !p.running {
+		p.mtx.Unlock()
+		return
+	}
+	if p.done == nil {
+		p.done = make(chan struct{})
+	}
+	done := p.done
+	p.notifyChange()
+	p.mtx.Unlock()
+
+	<-done
+	p.Stop()
+}
+
+// finished reports whether all the bars are complete or failed, and the last frame has been rendered.
+// The caller must hold the lock.
+func (p *Progress) finished() bool {
+	if p.paused {
+		return false
+	}
+	for _, bar := range p.Bars {
+		if !bar.Completed() && bar.Err() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseWaiters unblocks the callers of Wait. The caller must hold the lock.
+func (p *Progress) releaseWaiters() {
+	if p.done != nil {
+		close(p.done)
+		p.done = nil
+	}
+}
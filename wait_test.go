@@ -0,0 +1,26 @@
+package uiprogress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWait(t *testing.T) {
+	out := &syncBuffer{}
+	p := newTestProgress(out)
+	bar := p.Bars[0].AppendCompleted()
+	p.Wait()
+
+	p.Start()
+	go func() {
+		for bar.Incr() {
+		}
+	}()
+	p.Wait()
+	if p.running {
+		t.Fatal("want", "progress stopped", "got", "running")
+	}
+	if s := out.String(); !strings.HasSuffix(s, "100%\n") {
+		t.Fatalf("want the final frame, got %q", s)
+	}
+}